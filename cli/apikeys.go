@@ -0,0 +1,199 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/figment-networks/near-indexer/config"
+	"github.com/figment-networks/near-indexer/model"
+	"github.com/figment-networks/near-indexer/store"
+)
+
+const apiKeysUsage = `Usage: -cmd=apikeys COMMAND [OPTIONS]
+
+Commands:
+  list                 List all API keys
+  create -name=NAME    Generate a new API key
+  update -id=ID        Update API key limits
+  revoke -id=ID        Deactivate an API key
+  enable -id=ID        Activate a revoked API key
+  delete -id=ID        Delete an API key along with its usage`
+
+// apiKeyOptions contains API key attributes provided in the command line
+type apiKeyOptions struct {
+	id    int64
+	name  string
+	rate  float64
+	burst int
+	quota int64
+	admin bool
+	set   map[string]bool
+}
+
+func startAPIKeys(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return errors.New(apiKeysUsage)
+	}
+
+	opts, err := parseAPIKeyOptions(args[0], args[1:])
+	if err != nil {
+		return err
+	}
+
+	db, err := initStore(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	switch args[0] {
+	case "list":
+		return listAPIKeys(db)
+	case "create":
+		return createAPIKey(db, opts)
+	case "update":
+		return updateAPIKey(db, opts, nil)
+	case "revoke":
+		active := false
+		return updateAPIKey(db, opts, &active)
+	case "enable":
+		active := true
+		return updateAPIKey(db, opts, &active)
+	case "delete":
+		return deleteAPIKey(db, opts)
+	default:
+		return errors.New(apiKeysUsage)
+	}
+}
+
+func parseAPIKeyOptions(command string, args []string) (*apiKeyOptions, error) {
+	opts := &apiKeyOptions{set: map[string]bool{}}
+
+	flags := flag.NewFlagSet(command, flag.ContinueOnError)
+	flags.Int64Var(&opts.id, "id", 0, "API key ID")
+	flags.StringVar(&opts.name, "name", "", "API key name")
+	flags.Float64Var(&opts.rate, "rate", 0, "Requests per second, 0 to use the server default")
+	flags.IntVar(&opts.burst, "burst", 0, "Max burst of requests, 0 to use the server default")
+	flags.Int64Var(&opts.quota, "quota", 0, "Max number of requests per day, 0 for unlimited")
+	flags.BoolVar(&opts.admin, "admin", false, "Allow access to admin endpoints")
+
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	flags.Visit(func(f *flag.Flag) {
+		opts.set[f.Name] = true
+	})
+
+	return opts, nil
+}
+
+// assign copies the provided options to the API key
+func (opts *apiKeyOptions) assign(key *model.APIKey) {
+	if opts.set["name"] {
+		key.Name = opts.name
+	}
+	if opts.set["rate"] {
+		key.RateLimit = opts.rate
+	}
+	if opts.set["burst"] {
+		key.RateBurst = opts.burst
+	}
+	if opts.set["quota"] {
+		key.DailyQuota = opts.quota
+	}
+	if opts.set["admin"] {
+		key.Admin = opts.admin
+	}
+}
+
+func listAPIKeys(db *store.Store) error {
+	keys, err := db.APIKeys.All()
+	if err != nil {
+		return err
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetHeader([]string{"ID", "Name", "Prefix", "Rate", "Burst", "Quota", "Admin", "Active", "Last Used"})
+
+	for _, key := range keys {
+		lastUsed := "N/A"
+		if key.LastUsedAt != nil {
+			lastUsed = key.LastUsedAt.UTC().Format(time.RFC3339)
+		}
+
+		table.Append([]string{
+			fmt.Sprintf("%v", key.ID),
+			key.Name,
+			key.Prefix,
+			fmt.Sprintf("%v", key.RateLimit),
+			fmt.Sprintf("%v", key.RateBurst),
+			fmt.Sprintf("%v", key.DailyQuota),
+			fmt.Sprintf("%v", key.Admin),
+			fmt.Sprintf("%v", key.Active),
+			lastUsed,
+		})
+	}
+
+	table.Render()
+	return nil
+}
+
+func createAPIKey(db *store.Store, opts *apiKeyOptions) error {
+	key, token, err := model.NewAPIKey(opts.name)
+	if err != nil {
+		return err
+	}
+	opts.assign(key)
+
+	if err := key.Validate(); err != nil {
+		return err
+	}
+	if err := db.APIKeys.Create(key); err != nil {
+		return err
+	}
+
+	// Only the key digest is stored, the key can't be displayed again
+	fmt.Printf("Created API key %d (%s): %s\n", key.ID, key.Name, token)
+	return nil
+}
+
+func updateAPIKey(db *store.Store, opts *apiKeyOptions, active *bool) error {
+	key, err := db.APIKeys.FindByID(opts.id)
+	if err != nil {
+		return err
+	}
+
+	opts.assign(key)
+	if active != nil {
+		key.Active = *active
+	}
+
+	if err := key.Validate(); err != nil {
+		return err
+	}
+	if err := db.APIKeys.Update(key); err != nil {
+		return err
+	}
+
+	fmt.Printf("Updated API key %d (%s)\n", key.ID, key.Name)
+	return nil
+}
+
+func deleteAPIKey(db *store.Store, opts *apiKeyOptions) error {
+	key, err := db.APIKeys.FindByID(opts.id)
+	if err != nil {
+		return err
+	}
+
+	if !confirm(fmt.Sprintf("Are you sure you want to delete API key %d (%s)?", key.ID, key.Name)) {
+		return errors.New("aborted")
+	}
+
+	return db.APIKeys.Delete(key.ID)
+}
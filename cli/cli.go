@@ -72,8 +72,12 @@ func startCommand(cfg *config.Config, logger *logrus.Logger, name string) error
 		return startStatus(cfg)
 	case "cleanup":
 		return startCleanup(cfg, logger)
+	case "stats:backfill":
+		return startStatsBackfill(cfg, logger)
 	case "reset":
 		return startReset(cfg)
+	case "apikeys":
+		return startAPIKeys(cfg, flag.Args())
 	default:
 		return fmt.Errorf("%s is not a valid command", name)
 	}
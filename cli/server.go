@@ -7,6 +7,7 @@ import (
 
 	"github.com/figment-networks/near-indexer/config"
 	"github.com/figment-networks/near-indexer/near"
+	"github.com/figment-networks/near-indexer/prices"
 	"github.com/figment-networks/near-indexer/server"
 )
 
@@ -19,10 +20,26 @@ func startServer(cfg *config.Config, logger *logrus.Logger) error {
 	}
 	defer db.Close()
 
+	if readURLs := cfg.DatabaseReadURLs(); len(readURLs) > 0 {
+		if err := db.UseReplicas(readURLs, cfg.ReadMaxLagDuration()); err != nil {
+			return err
+		}
+		logger.WithField("count", len(readURLs)).Info("using read replicas")
+	}
+
 	rpcEndpoints := strings.Split(cfg.RPCEndpoints, ",")
 	rpc := near.DefaultClient(rpcEndpoints[0])
 
-	srv := server.New(cfg, db, logger, rpc)
+	var priceSource prices.Source
+	if cfg.PricesFile != "" {
+		src, err := prices.NewCSVFileSource(cfg.PricesFile)
+		if err != nil {
+			return err
+		}
+		priceSource = src
+	}
+
+	srv := server.New(cfg, db, logger, rpc, priceSource)
 
 	logger.Info("Starting server on ", cfg.ListenAddr())
 	return srv.Run(cfg.ListenAddr())
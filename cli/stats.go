@@ -0,0 +1,18 @@
+package cli
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"github.com/figment-networks/near-indexer/config"
+	"github.com/figment-networks/near-indexer/pipeline"
+)
+
+func startStatsBackfill(cfg *config.Config, logger *logrus.Logger) error {
+	db, err := initStore(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return pipeline.RunStatsBackfill(cfg, db, logger)
+}
@@ -79,11 +79,37 @@ func startCleanupWorker(wg *sync.WaitGroup, cfg *config.Config, db *store.Store,
 	return cancel
 }
 
+func startWebhooksWorker(wg *sync.WaitGroup, cfg *config.Config, db *store.Store, logger *logrus.Logger) context.CancelFunc {
+	ctx, cancel := context.WithCancel(context.Background())
+	ticker := time.NewTicker(cfg.WebhooksDuration())
+
+	go func() {
+		defer func() {
+			ticker.Stop()
+			wg.Done()
+		}()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := pipeline.RunWebhookDeliveries(cfg, db, logger); err != nil {
+					logger.WithError(err).Error("webhook deliveries failed")
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return cancel
+}
+
 func startWorker(cfg *config.Config, logger *logrus.Logger) error {
 	logger.Info("log level: ", cfg.LogLevel)
 	logger.Info("using rpc endpoints: ", cfg.RPCEndpoints)
 	logger.Info("sync will run every: ", cfg.SyncInterval)
 	logger.Info("cleanup will run every: ", cfg.CleanupInterval)
+	logger.Info("webhooks will run every: ", cfg.WebhooksInterval)
 
 	db, err := initStore(cfg)
 	if err != nil {
@@ -92,16 +118,18 @@ func startWorker(cfg *config.Config, logger *logrus.Logger) error {
 	defer db.Close()
 
 	wg := &sync.WaitGroup{}
-	wg.Add(2)
+	wg.Add(3)
 
 	cancelSync := startSyncWorker(wg, cfg, db)
 	cancelCleanup := startCleanupWorker(wg, cfg, db, logger)
+	cancelWebhooks := startWebhooksWorker(wg, cfg, db, logger)
 
 	s := <-initSignals()
 	logger.Info("received signal: ", s)
 
 	cancelSync()
 	cancelCleanup()
+	cancelWebhooks()
 
 	wg.Wait()
 	return nil
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net"
+	"strings"
 	"time"
 
 	"github.com/kelseyhightower/envconfig"
@@ -24,6 +26,10 @@ var (
 	errCleanupIntervalRequired = errors.New("Cleanup interval is required")
 	errCleanupIntervalInvalid  = errors.New("Cleanup interval is invalid")
 	errRPCTimeoutInvalid       = errors.New("RPC timeout interval is invalid")
+	errWebhooksIntervalInvalid = errors.New("Webhooks interval is invalid")
+	errRateLimitInvalid        = errors.New("Rate limit is invalid")
+	errReadMaxLagInvalid       = errors.New("Read replica max lag is invalid")
+	errTrustedProxiesInvalid   = errors.New("Trusted proxies are invalid")
 )
 
 // Config holds the configration data
@@ -38,10 +44,24 @@ type Config struct {
 	SyncBatchSize    int    `json:"sync_batch_size" envconfig:"SYNC_BATCH_SIZE" default:"10"`
 	CleanupInterval  string `json:"cleanup_interval" envconfig:"CLEANUP_INTERVAL" default:"10m"`
 	CleanupThreshold int    `json:"cleanup_threshold" envconfig:"CLEANUP_THRESHOLD" default:"3600"`
+	WebhooksInterval string `json:"webhooks_interval" envconfig:"WEBHOOKS_INTERVAL" default:"5s"`
 	DatabaseURL      string `json:"database_url" envconfig:"DATABASE_URL"`
+	DatabaseReadURL  string `json:"database_read_url" envconfig:"DATABASE_READ_URL"`
+	ReadMaxLag       string `json:"database_read_max_lag" envconfig:"DATABASE_READ_MAX_LAG" default:"10s"`
 	DumpDir          string `json:"dump_dir" envconfig:"DUMP_DIR"`
 	Debug            bool   `json:"debug" envconfig:"DEBUG"`
 	LogLevel         string `json:"log_level" envconfig:"LOG_LEVEL" default:"info"`
+	PricesFile       string `json:"prices_file" envconfig:"PRICES_FILE"`
+	RosettaEnabled   bool   `json:"rosetta_enabled" envconfig:"ROSETTA_ENABLED"`
+	ResponseCache    bool   `json:"response_cache" envconfig:"RESPONSE_CACHE" default:"true"`
+
+	// API access
+	APIKeysRequired   bool    `json:"api_keys_required" envconfig:"API_KEYS_REQUIRED"`
+	RateLimitIP       float64 `json:"rate_limit_ip" envconfig:"RATE_LIMIT_IP"`
+	RateLimitIPBurst  int     `json:"rate_limit_ip_burst" envconfig:"RATE_LIMIT_IP_BURST" default:"20"`
+	RateLimitKey      float64 `json:"rate_limit_key" envconfig:"RATE_LIMIT_KEY"`
+	RateLimitKeyBurst int     `json:"rate_limit_key_burst" envconfig:"RATE_LIMIT_KEY_BURST" default:"50"`
+	TrustedProxies    string  `json:"trusted_proxies" envconfig:"TRUSTED_PROXIES"`
 
 	// delegation calls
 	RetryCountDlg    int `json:"retry_count_delegation_calls" envconfig:"RETRY_COUNT_DELEGATION_CALLS" default:"4"`
@@ -51,9 +71,12 @@ type Config struct {
 	RollbarToken     string `json:"rollbar_token" envconfig:"ROLLBAR_TOKEN"`
 	RollbarNamespace string `json:"rollbar_namespace" envconfig:"ROLLBAR_NAMESPACE"`
 
-	syncDuration    time.Duration
-	cleanupDuration time.Duration
-	rpcTimeout      time.Duration
+	syncDuration     time.Duration
+	cleanupDuration  time.Duration
+	rpcTimeout       time.Duration
+	webhooksDuration time.Duration
+	readMaxLag       time.Duration
+	trustedProxies   []*net.IPNet
 }
 
 // Validate returns an error if config is invalid
@@ -91,6 +114,47 @@ func (c *Config) Validate() error {
 	}
 	c.rpcTimeout = rpcTimeout
 
+	if c.WebhooksInterval == "" {
+		c.WebhooksInterval = "5s"
+	}
+	webhooksDuration, err := time.ParseDuration(c.WebhooksInterval)
+	if err != nil {
+		return errWebhooksIntervalInvalid
+	}
+	c.webhooksDuration = webhooksDuration
+
+	if c.ReadMaxLag == "" {
+		c.ReadMaxLag = "10s"
+	}
+	readMaxLag, err := time.ParseDuration(c.ReadMaxLag)
+	if err != nil || readMaxLag < 0 {
+		return errReadMaxLagInvalid
+	}
+	c.readMaxLag = readMaxLag
+
+	if c.RateLimitIP < 0 || c.RateLimitIPBurst < 0 || c.RateLimitKey < 0 || c.RateLimitKeyBurst < 0 {
+		return errRateLimitInvalid
+	}
+
+	c.trustedProxies = nil
+	for _, proxy := range strings.Split(c.TrustedProxies, ",") {
+		if proxy = strings.TrimSpace(proxy); proxy == "" {
+			continue
+		}
+		if !strings.Contains(proxy, "/") {
+			if strings.Contains(proxy, ":") {
+				proxy += "/128"
+			} else {
+				proxy += "/32"
+			}
+		}
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return errTrustedProxiesInvalid
+		}
+		c.trustedProxies = append(c.trustedProxies, network)
+	}
+
 	return nil
 }
 
@@ -119,6 +183,32 @@ func (c *Config) CleanupDuration() time.Duration {
 	return c.cleanupDuration
 }
 
+// WebhooksDuration returns the parsed duration for the webhook deliveries
+func (c *Config) WebhooksDuration() time.Duration {
+	return c.webhooksDuration
+}
+
+// DatabaseReadURLs returns the list of read replica URLs
+func (c *Config) DatabaseReadURLs() []string {
+	urls := []string{}
+	for _, url := range strings.Split(c.DatabaseReadURL, ",") {
+		if url = strings.TrimSpace(url); url != "" {
+			urls = append(urls, url)
+		}
+	}
+	return urls
+}
+
+// ReadMaxLagDuration returns the max replication lag allowed for read replicas
+func (c *Config) ReadMaxLagDuration() time.Duration {
+	return c.readMaxLag
+}
+
+// TrustedProxyNetworks returns the networks of proxies allowed to set the client address
+func (c *Config) TrustedProxyNetworks() []*net.IPNet {
+	return c.trustedProxies
+}
+
 // RPCClientTimeout returns the timeout value for RPC calls
 func (c *Config) RPCClientTimeout() time.Duration {
 	return c.rpcTimeout
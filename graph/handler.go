@@ -0,0 +1,81 @@
+package graph
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/figment-networks/near-indexer/store"
+)
+
+const (
+	maxQueryDepth  = 8
+	maxParallelism = 20
+)
+
+type request struct {
+	Query         string                 `json:"query" form:"query"`
+	OperationName string                 `json:"operationName" form:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Handler serves GraphQL queries over HTTP
+type Handler struct {
+	db     dataStore
+	schema *graphql.Schema
+}
+
+// NewHandler returns a new GraphQL handler
+func NewHandler(db *store.Store) *Handler {
+	return newHandler(newDataStore(db))
+}
+
+func newHandler(db dataStore) *Handler {
+	schema := graphql.MustParseSchema(Schema, &Resolver{db: db},
+		graphql.MaxDepth(maxQueryDepth),
+		graphql.MaxParallelism(maxParallelism),
+	)
+
+	return &Handler{
+		db:     db,
+		schema: schema,
+	}
+}
+
+// ServeHTTP executes a query passed in a JSON body or GET query params
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	req := request{}
+
+	switch r.Method {
+	case http.MethodGet:
+		q := r.URL.Query()
+		req.Query = q.Get("query")
+		req.OperationName = q.Get("operationName")
+		if vars := q.Get("variables"); vars != "" {
+			if err := json.Unmarshal([]byte(vars), &req.Variables); err != nil {
+				http.Error(w, "variables are invalid", http.StatusBadRequest)
+				return
+			}
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "request body is invalid", http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if req.Query == "" {
+		http.Error(w, "query is required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := withLoaders(r.Context(), h.db)
+	response := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
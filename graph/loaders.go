@@ -0,0 +1,225 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/graph-gophers/dataloader"
+
+	"github.com/figment-networks/near-indexer/model"
+	"github.com/figment-networks/near-indexer/store"
+)
+
+const (
+	loaderWait     = 5 * time.Millisecond
+	loaderCapacity = 100
+)
+
+type loadersKey struct{}
+
+// loaders batch related record lookups made while resolving a single query
+type loaders struct {
+	blocksByHeight *dataloader.Loader
+	blocksByHash   *dataloader.Loader
+	epochs         *dataloader.Loader
+	validators     *dataloader.Loader
+	accounts       *dataloader.Loader
+
+	// relations are to-many lookups keyed by the parent record, one loader
+	// per relation and arguments
+	relations   map[string]*dataloader.Loader
+	relationsMu sync.Mutex
+}
+
+// withLoaders returns a context with a fresh set of loaders attached
+func withLoaders(ctx context.Context, db dataStore) context.Context {
+	l := &loaders{
+		relations: map[string]*dataloader.Loader{},
+
+		blocksByHeight: newLoader(func(keys []string) (map[string]interface{}, error) {
+			heights := make([]uint64, 0, len(keys))
+			for _, key := range keys {
+				if h, err := strconv.ParseUint(key, 10, 64); err == nil {
+					heights = append(heights, h)
+				}
+			}
+
+			blocks, err := db.Blocks.FindByHeights(heights)
+			if err != nil {
+				return nil, err
+			}
+
+			result := map[string]interface{}{}
+			for _, b := range blocks {
+				result[b.ID.String()] = b
+			}
+			return result, nil
+		}),
+
+		blocksByHash: newLoader(func(keys []string) (map[string]interface{}, error) {
+			blocks, err := db.Blocks.FindByHashes(keys)
+			if err != nil {
+				return nil, err
+			}
+
+			result := map[string]interface{}{}
+			for _, b := range blocks {
+				result[b.Hash] = b
+			}
+			return result, nil
+		}),
+
+		epochs: newLoader(func(keys []string) (map[string]interface{}, error) {
+			epochs, err := db.Epochs.FindByIDs(keys)
+			if err != nil {
+				return nil, err
+			}
+
+			result := map[string]interface{}{}
+			for _, e := range epochs {
+				result[e.ID] = e
+			}
+			return result, nil
+		}),
+
+		validators: newLoader(func(keys []string) (map[string]interface{}, error) {
+			validators, err := db.ValidatorAggs.FindByAccountIDs(keys)
+			if err != nil {
+				return nil, err
+			}
+
+			result := map[string]interface{}{}
+			for _, v := range validators {
+				result[v.AccountID] = v
+			}
+			return result, nil
+		}),
+
+		accounts: newLoader(func(keys []string) (map[string]interface{}, error) {
+			accounts, err := db.Accounts.FindByNames(keys)
+			if err != nil {
+				return nil, err
+			}
+
+			result := map[string]interface{}{}
+			for _, a := range accounts {
+				result[a.Name] = a
+			}
+			return result, nil
+		}),
+	}
+
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+// newLoader returns a batched loader that fetches all keys of a batch at once.
+// Keys missing from the fetched set resolve to nil.
+func newLoader(fetch func(keys []string) (map[string]interface{}, error)) *dataloader.Loader {
+	batch := func(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+		records, err := fetch(keys.Keys())
+
+		results := make([]*dataloader.Result, len(keys))
+		for i, key := range keys {
+			results[i] = &dataloader.Result{Data: records[key.String()], Error: err}
+		}
+		return results
+	}
+
+	return dataloader.NewBatchedLoader(batch,
+		dataloader.WithWait(loaderWait),
+		dataloader.WithBatchCapacity(loaderCapacity),
+	)
+}
+
+// relation returns the loader of a to-many relation for the given arguments.
+// All parent records resolving the same field share the loader, so the
+// relation is fetched in a single query per batch.
+func (l *loaders) relation(name string, args interface{}, fetch func(keys []string) (map[string]interface{}, error)) *dataloader.Loader {
+	key := fmt.Sprintf("%s:%+v", name, args)
+
+	l.relationsMu.Lock()
+	defer l.relationsMu.Unlock()
+
+	loader, ok := l.relations[key]
+	if !ok {
+		loader = newLoader(fetch)
+		l.relations[key] = loader
+	}
+	return loader
+}
+
+func contextLoaders(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+func load(ctx context.Context, loader *dataloader.Loader, key string) (interface{}, error) {
+	return loader.Load(ctx, dataloader.StringKey(key))()
+}
+
+func loadRelation(ctx context.Context, name string, args interface{}, key string, fetch func(keys []string) (map[string]interface{}, error)) (interface{}, error) {
+	return load(ctx, contextLoaders(ctx).relation(name, args, fetch), key)
+}
+
+func loadBlockByHeight(ctx context.Context, height uint64) (*model.Block, error) {
+	data, err := load(ctx, contextLoaders(ctx).blocksByHeight, strconv.FormatUint(height, 10))
+	if err != nil || data == nil {
+		return nil, err
+	}
+	block := data.(model.Block)
+	return &block, nil
+}
+
+func loadBlockByHash(ctx context.Context, hash string) (*model.Block, error) {
+	data, err := load(ctx, contextLoaders(ctx).blocksByHash, hash)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	block := data.(model.Block)
+	return &block, nil
+}
+
+func loadEpoch(ctx context.Context, id string) (*model.Epoch, error) {
+	data, err := load(ctx, contextLoaders(ctx).epochs, id)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	epoch := data.(model.Epoch)
+	return &epoch, nil
+}
+
+func loadValidator(ctx context.Context, id string) (*model.ValidatorAgg, error) {
+	data, err := load(ctx, contextLoaders(ctx).validators, id)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	validator := data.(model.ValidatorAgg)
+	return &validator, nil
+}
+
+func loadAccount(ctx context.Context, name string) (*model.Account, error) {
+	data, err := load(ctx, contextLoaders(ctx).accounts, name)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	account := data.(model.Account)
+	return &account, nil
+}
+
+func loadPaginatedRelation(ctx context.Context, name string, args interface{}, key string, fetch func(keys []string) (map[string]*store.PaginatedResult, error)) (*store.PaginatedResult, error) {
+	data, err := loadRelation(ctx, name, args, key, func(keys []string) (map[string]interface{}, error) {
+		results, err := fetch(keys)
+
+		records := make(map[string]interface{}, len(results))
+		for k, result := range results {
+			records[k] = result
+		}
+		return records, err
+	})
+	if err != nil || data == nil {
+		return nil, err
+	}
+	return data.(*store.PaginatedResult), nil
+}
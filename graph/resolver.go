@@ -0,0 +1,252 @@
+package graph
+
+import (
+	"context"
+	"errors"
+
+	"github.com/figment-networks/near-indexer/model"
+	"github.com/figment-networks/near-indexer/store"
+)
+
+var (
+	errBlockLookup = errors.New("height or hash is required")
+)
+
+// Resolver resolves the root query fields
+type Resolver struct {
+	db dataStore
+}
+
+type paginationArgs struct {
+	Page      *int32
+	Limit     *int32
+	Cursor    *string
+	SkipCount *bool
+}
+
+func (args paginationArgs) pagination() store.Pagination {
+	p := store.Pagination{
+		Page:   uintArg(args.Page),
+		Limit:  uintArg(args.Limit),
+		Cursor: stringArg(args.Cursor),
+	}
+	if args.SkipCount != nil {
+		p.SkipCount = *args.SkipCount
+	}
+	return p
+}
+
+func (r *Resolver) Block(ctx context.Context, args struct {
+	Height *int32
+	Hash   *string
+}) (*blockResolver, error) {
+	var block *model.Block
+	var err error
+
+	switch {
+	case args.Height != nil:
+		block, err = r.db.Blocks.FindByHeight(uint64(*args.Height))
+	case args.Hash != nil:
+		block, err = r.db.Blocks.FindByHash(*args.Hash)
+	default:
+		return nil, errBlockLookup
+	}
+	if err != nil {
+		return nil, skipNotFound(err)
+	}
+
+	return &blockResolver{r, *block}, nil
+}
+
+func (r *Resolver) Blocks(ctx context.Context, args struct {
+	Producer        *string
+	Epoch           *string
+	MinHeight       *int32
+	MaxHeight       *int32
+	MinTransactions *int32
+	StartDate       *string
+	EndDate         *string
+	Page            *int32
+	Limit           *int32
+}) (*blockConnectionResolver, error) {
+	search := store.BlocksSearch{
+		Pagination:      paginationArgs{Page: args.Page, Limit: args.Limit}.pagination(),
+		Producer:        stringArg(args.Producer),
+		Epoch:           stringArg(args.Epoch),
+		MinHeight:       uint64(uintArg(args.MinHeight)),
+		MaxHeight:       uint64(uintArg(args.MaxHeight)),
+		MinTransactions: int(uintArg(args.MinTransactions)),
+		StartDate:       stringArg(args.StartDate),
+		EndDate:         stringArg(args.EndDate),
+	}
+
+	result, err := r.db.Blocks.Search(search)
+	if err != nil {
+		return nil, err
+	}
+
+	return &blockConnectionResolver{r, result}, nil
+}
+
+func (r *Resolver) Epoch(ctx context.Context, args struct{ ID string }) (*epochResolver, error) {
+	epoch, err := r.db.Epochs.FindByID(args.ID)
+	if err != nil {
+		return nil, skipNotFound(err)
+	}
+	return &epochResolver{r, *epoch}, nil
+}
+
+func (r *Resolver) Epochs(ctx context.Context, args struct{ Limit *int32 }) ([]*epochResolver, error) {
+	limit := int(uintArg(args.Limit))
+	if limit == 0 || limit > 100 {
+		limit = 100
+	}
+
+	epochs, err := r.db.Epochs.Recent(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*epochResolver, len(epochs))
+	for i, e := range epochs {
+		result[i] = &epochResolver{r, e}
+	}
+	return result, nil
+}
+
+func (r *Resolver) Transaction(ctx context.Context, args struct{ Hash string }) (*transactionResolver, error) {
+	tx, err := r.db.Transactions.FindByHash(args.Hash)
+	if err != nil {
+		return nil, skipNotFound(err)
+	}
+	return &transactionResolver{r, *tx}, nil
+}
+
+func (r *Resolver) Transactions(ctx context.Context, args struct {
+	Account     *string
+	Sender      *string
+	Receiver    *string
+	BlockHash   *string
+	BlockHeight *int32
+	StartDate   *string
+	EndDate     *string
+	paginationArgs
+}) (*transactionConnectionResolver, error) {
+	search := store.TransactionsSearch{
+		Pagination:  args.pagination(),
+		Account:     stringArg(args.Account),
+		Sender:      stringArg(args.Sender),
+		Receiver:    stringArg(args.Receiver),
+		BlockHash:   stringArg(args.BlockHash),
+		BlockHeight: uint64(uintArg(args.BlockHeight)),
+		StartDate:   stringArg(args.StartDate),
+		EndDate:     stringArg(args.EndDate),
+	}
+
+	result, err := r.db.Transactions.Search(search)
+	if err != nil {
+		return nil, err
+	}
+
+	return &transactionConnectionResolver{r, result}, nil
+}
+
+func (r *Resolver) Validator(ctx context.Context, args struct{ ID string }) (*validatorResolver, error) {
+	return r.validator(ctx, args.ID)
+}
+
+func (r *Resolver) Validators(ctx context.Context, args struct{ All *bool }) ([]*validatorResolver, error) {
+	var validators []model.ValidatorAgg
+	var err error
+
+	if args.All != nil && *args.All {
+		validators, err = r.db.ValidatorAggs.All()
+	} else {
+		validators, err = r.db.ValidatorAggs.Top()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*validatorResolver, len(validators))
+	for i, v := range validators {
+		result[i] = &validatorResolver{r, v}
+	}
+	return result, nil
+}
+
+func (r *Resolver) Account(ctx context.Context, args struct{ ID string }) (*accountResolver, error) {
+	return r.account(ctx, args.ID)
+}
+
+func (r *Resolver) DelegatorEpochs(ctx context.Context, args struct {
+	AccountID   *string
+	ValidatorID *string
+	Epoch       *string
+	StartDate   *string
+	EndDate     *string
+	paginationArgs
+}) (*delegatorEpochConnectionResolver, error) {
+	search := store.DelegatorEpochsSearch{
+		AccountID:   stringArg(args.AccountID),
+		ValidatorID: stringArg(args.ValidatorID),
+		Epoch:       stringArg(args.Epoch),
+		StartDate:   stringArg(args.StartDate),
+		EndDate:     stringArg(args.EndDate),
+	}
+
+	result, err := r.db.Delegators.PaginateDelegatorEpochs(search, args.pagination())
+	if err != nil {
+		return nil, err
+	}
+
+	return &delegatorEpochConnectionResolver{r, result}, nil
+}
+
+func (r *Resolver) Events(ctx context.Context, args struct {
+	ItemID   *string
+	ItemType *string
+	Action   *string
+	Height   *int32
+	Epoch    *string
+	paginationArgs
+}) (*eventConnectionResolver, error) {
+	return r.events(store.EventsSearch{
+		Pagination: args.pagination(),
+		ItemID:     stringArg(args.ItemID),
+		ItemType:   stringArg(args.ItemType),
+		Action:     stringArg(args.Action),
+		Height:     uint64(uintArg(args.Height)),
+		Epoch:      stringArg(args.Epoch),
+	})
+}
+
+func (r *Resolver) events(search store.EventsSearch) (*eventConnectionResolver, error) {
+	result, err := r.db.Events.Search(search)
+	if err != nil {
+		return nil, err
+	}
+	return &eventConnectionResolver{r, result}, nil
+}
+
+// skipNotFound turns missing records into null values
+func skipNotFound(err error) error {
+	if err == store.ErrNotFound {
+		return nil
+	}
+	return err
+}
+
+func stringArg(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func uintArg(n *int32) uint {
+	if n == nil || *n < 0 {
+		return 0
+	}
+	return uint(*n)
+}
@@ -0,0 +1,335 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/figment-networks/near-indexer/model"
+	"github.com/figment-networks/near-indexer/store"
+)
+
+// fixtureCalls records the keys of batched store calls
+type fixtureCalls struct {
+	sync.Mutex
+	keys map[string][][]string
+}
+
+func (c *fixtureCalls) record(name string, keys []string) {
+	c.Lock()
+	defer c.Unlock()
+
+	sorted := append([]string{}, keys...)
+	sort.Strings(sorted)
+	c.keys[name] = append(c.keys[name], sorted)
+}
+
+type fixtureBlocks struct {
+	calls  *fixtureCalls
+	blocks []model.Block
+}
+
+func (s fixtureBlocks) FindByHash(string) (*model.Block, error)       { return nil, store.ErrNotFound }
+func (s fixtureBlocks) FindByHashes([]string) ([]model.Block, error)  { return nil, nil }
+func (s fixtureBlocks) FindByHeight(uint64) (*model.Block, error)     { return nil, store.ErrNotFound }
+func (s fixtureBlocks) FindByHeights([]uint64) ([]model.Block, error) { return nil, nil }
+func (s fixtureBlocks) Search(store.BlocksSearch) (*store.PaginatedResult, error) {
+	return &store.PaginatedResult{Records: s.blocks}, nil
+}
+
+func (s fixtureBlocks) SearchByProducers(producers []string, p store.Pagination) (map[string]*store.PaginatedResult, error) {
+	s.calls.record("blocks", producers)
+
+	result := map[string]*store.PaginatedResult{}
+	for _, producer := range producers {
+		blocks := []model.Block{}
+		for _, b := range s.blocks {
+			if b.Producer == producer && (p.Limit == 0 || uint(len(blocks)) < p.Limit) {
+				blocks = append(blocks, b)
+			}
+		}
+		result[producer] = &store.PaginatedResult{Count: uint(len(blocks)), Records: blocks}
+	}
+	return result, nil
+}
+
+type fixtureDelegators struct {
+	calls *fixtureCalls
+}
+
+func (s fixtureDelegators) PaginateDelegatorEpochs(search store.DelegatorEpochsSearch, p store.Pagination) (*store.PaginatedResult, error) {
+	s.calls.record("delegatorEpochs", []string{search.ValidatorID, p.Cursor})
+
+	epochs := []model.DelegatorEpoch{}
+	for i := uint(0); i < p.Limit; i++ {
+		epochs = append(epochs, model.DelegatorEpoch{AccountID: "delegator", ValidatorID: search.ValidatorID})
+	}
+	return &store.PaginatedResult{Limit: p.Limit, Count: 100, NextCursor: "next", Records: epochs}, nil
+}
+
+func (s fixtureDelegators) FindByValidators(ids []string, epoch string) (map[string][]model.DelegatorEpoch, error) {
+	s.calls.record("delegators", ids)
+
+	result := map[string][]model.DelegatorEpoch{}
+	for _, id := range ids {
+		result[id] = []model.DelegatorEpoch{{AccountID: "delegator-" + id, ValidatorID: id, Epoch: epoch}}
+	}
+	return result, nil
+}
+
+func (s fixtureDelegators) FindByAccounts([]string, string, string) (map[string][]model.DelegatorEpoch, error) {
+	return nil, nil
+}
+
+type fixtureEvents struct {
+	calls *fixtureCalls
+}
+
+func (s fixtureEvents) Search(store.EventsSearch) (*store.PaginatedResult, error) { return nil, nil }
+
+func (s fixtureEvents) SearchByItems(itemType string, ids []string, p store.Pagination) (map[string]*store.PaginatedResult, error) {
+	s.calls.record("events", ids)
+
+	result := map[string]*store.PaginatedResult{}
+	for _, id := range ids {
+		events := []model.Event{{ItemID: id, ItemType: itemType, Action: "joined"}}
+		result[id] = &store.PaginatedResult{Count: 1, Records: events}
+	}
+	return result, nil
+}
+
+func (s fixtureEvents) SearchByEpochs([]string, store.Pagination) (map[string]*store.PaginatedResult, error) {
+	return nil, nil
+}
+
+func (s fixtureEvents) SearchByHeights([]uint64, store.Pagination) (map[string]*store.PaginatedResult, error) {
+	return nil, nil
+}
+
+type fixtureTransactions struct {
+	calls        *fixtureCalls
+	transactions []model.Transaction
+}
+
+func (s fixtureTransactions) FindByHash(string) (*model.Transaction, error) {
+	return nil, store.ErrNotFound
+}
+
+func (s fixtureTransactions) FindByBlocks(hashes []string) (map[string][]model.Transaction, error) {
+	s.calls.record("transactions", hashes)
+
+	result := map[string][]model.Transaction{}
+	for _, t := range s.transactions {
+		result[t.BlockHash] = append(result[t.BlockHash], t)
+	}
+	return result, nil
+}
+
+func (s fixtureTransactions) Search(store.TransactionsSearch) (*store.PaginatedResult, error) {
+	return nil, nil
+}
+
+func (s fixtureTransactions) SearchByAccounts([]string, store.Pagination) (map[string]*store.PaginatedResult, error) {
+	return nil, nil
+}
+
+type fixtureValidatorAggs struct {
+	calls      *fixtureCalls
+	validators []model.ValidatorAgg
+}
+
+func (s fixtureValidatorAggs) All() ([]model.ValidatorAgg, error) { return s.validators, nil }
+func (s fixtureValidatorAggs) Top() ([]model.ValidatorAgg, error) { return s.validators, nil }
+
+func (s fixtureValidatorAggs) FindByAccountIDs([]string) ([]model.ValidatorAgg, error) {
+	return nil, nil
+}
+
+func (s fixtureValidatorAggs) PaginateValidatorEpochsByAccounts(accounts []string, p store.Pagination) (map[string]*store.PaginatedResult, error) {
+	s.calls.record("epochs", accounts)
+
+	result := map[string]*store.PaginatedResult{}
+	for _, account := range accounts {
+		epochs := []model.ValidatorEpoch{{AccountID: account, Epoch: "epoch-" + account}}
+		result[account] = &store.PaginatedResult{Count: 1, Records: epochs}
+	}
+	return result, nil
+}
+
+func testFixture() (dataStore, *fixtureCalls) {
+	calls := &fixtureCalls{keys: map[string][][]string{}}
+
+	validators := []model.ValidatorAgg{
+		{AccountID: "a.near"},
+		{AccountID: "b.near"},
+		{AccountID: "c.near"},
+	}
+
+	blocks := []model.Block{
+		{ID: 3, Hash: "h3", Producer: "a.near", TransactionsCount: 1},
+		{ID: 2, Hash: "h2", Producer: "b.near", TransactionsCount: 2},
+		{ID: 1, Hash: "h1", Producer: "a.near"},
+	}
+
+	transactions := []model.Transaction{
+		{Hash: "t1", BlockHash: "h3"},
+		{Hash: "t2", BlockHash: "h2"},
+		{Hash: "t3", BlockHash: "h2"},
+	}
+
+	db := dataStore{
+		Blocks:        fixtureBlocks{calls: calls, blocks: blocks},
+		Delegators:    fixtureDelegators{calls: calls},
+		Events:        fixtureEvents{calls: calls},
+		Transactions:  fixtureTransactions{calls: calls, transactions: transactions},
+		ValidatorAggs: fixtureValidatorAggs{calls: calls, validators: validators},
+	}
+
+	return db, calls
+}
+
+func execQuery(t *testing.T, db dataStore, query string, dst interface{}) {
+	h := newHandler(db)
+
+	response := h.schema.Exec(withLoaders(context.Background(), db), query, "", nil)
+	if !assert.Empty(t, response.Errors) {
+		return
+	}
+	assert.NoError(t, json.Unmarshal(response.Data, dst))
+}
+
+func TestValidatorRelations(t *testing.T) {
+	db, calls := testFixture()
+
+	result := struct {
+		Validators []struct {
+			AccountID string
+			Blocks    struct {
+				PageInfo struct{ Count int }
+				Records  []struct{ Height int }
+			}
+			Epochs struct {
+				Records []struct{ EpochID string }
+			}
+			Events struct {
+				Records []struct{ ItemID string }
+			}
+			Delegators []struct{ AccountID string }
+		}
+	}{}
+
+	execQuery(t, db, `{
+		validators(all: true) {
+			accountId
+			blocks(limit: 10) { pageInfo { count } records { height } }
+			epochs { records { epochId } }
+			events { records { itemId } }
+			delegators { accountId }
+		}
+	}`, &result)
+
+	all := []string{"a.near", "b.near", "c.near"}
+	assert.Equal(t, [][]string{all}, calls.keys["blocks"])
+	assert.Equal(t, [][]string{all}, calls.keys["epochs"])
+	assert.Equal(t, [][]string{all}, calls.keys["events"])
+	assert.Equal(t, [][]string{all}, calls.keys["delegators"])
+
+	if assert.Len(t, result.Validators, 3) {
+		a := result.Validators[0]
+		assert.Equal(t, "a.near", a.AccountID)
+		assert.Equal(t, 2, a.Blocks.PageInfo.Count)
+		assert.Len(t, a.Blocks.Records, 2)
+		assert.Equal(t, "epoch-a.near", a.Epochs.Records[0].EpochID)
+		assert.Equal(t, "a.near", a.Events.Records[0].ItemID)
+		assert.Equal(t, "delegator-a.near", a.Delegators[0].AccountID)
+
+		c := result.Validators[2]
+		assert.Equal(t, 0, c.Blocks.PageInfo.Count)
+		assert.Empty(t, c.Blocks.Records)
+	}
+}
+
+func TestValidatorRelationsWithArgs(t *testing.T) {
+	db, calls := testFixture()
+
+	result := struct {
+		Validators []struct {
+			First struct {
+				Records []struct{ Height int }
+			}
+			All struct {
+				Records []struct{ Height int }
+			}
+		}
+	}{}
+
+	execQuery(t, db, `{
+		validators {
+			first: blocks(limit: 1) { records { height } }
+			all: blocks(limit: 10) { records { height } }
+		}
+	}`, &result)
+
+	assert.Len(t, calls.keys["blocks"], 2)
+
+	if assert.Len(t, result.Validators, 3) {
+		assert.Len(t, result.Validators[0].First.Records, 1)
+		assert.Len(t, result.Validators[0].All.Records, 2)
+	}
+}
+
+func TestBlockTransactions(t *testing.T) {
+	db, calls := testFixture()
+
+	result := struct {
+		Blocks struct {
+			Records []struct {
+				Hash         string
+				Transactions []struct{ Hash string }
+			}
+		}
+	}{}
+
+	execQuery(t, db, `{
+		blocks { records { hash transactions { hash } } }
+	}`, &result)
+
+	// Blocks without transactions are not looked up
+	assert.Equal(t, [][]string{{"h2", "h3"}}, calls.keys["transactions"])
+
+	if assert.Len(t, result.Blocks.Records, 3) {
+		assert.Len(t, result.Blocks.Records[0].Transactions, 1)
+		assert.Len(t, result.Blocks.Records[1].Transactions, 2)
+		assert.Empty(t, result.Blocks.Records[2].Transactions)
+	}
+}
+
+func TestDelegatorEpochsPagination(t *testing.T) {
+	db, calls := testFixture()
+
+	result := struct {
+		DelegatorEpochs struct {
+			PageInfo struct {
+				Count      int
+				NextCursor string
+			}
+			Records []struct{ ValidatorID string }
+		}
+	}{}
+
+	execQuery(t, db, `{
+		delegatorEpochs(validatorId: "a.near", limit: 2, cursor: "cursor") {
+			pageInfo { count nextCursor }
+			records { validatorId }
+		}
+	}`, &result)
+
+	assert.Equal(t, [][]string{{"a.near", "cursor"}}, calls.keys["delegatorEpochs"])
+	assert.Equal(t, 100, result.DelegatorEpochs.PageInfo.Count)
+	assert.Equal(t, "next", result.DelegatorEpochs.PageInfo.NextCursor)
+	assert.Len(t, result.DelegatorEpochs.Records, 2)
+}
@@ -0,0 +1,197 @@
+package graph
+
+// Schema is the GraphQL schema definition of the indexer API
+const Schema = `
+scalar Time
+
+schema {
+	query: Query
+}
+
+type Query {
+	block(height: Int, hash: String): Block
+	blocks(producer: String, epoch: String, minHeight: Int, maxHeight: Int, minTransactions: Int, startDate: String, endDate: String, page: Int, limit: Int): BlockConnection!
+	epoch(id: String!): Epoch
+	epochs(limit: Int): [Epoch!]!
+	transaction(hash: String!): Transaction
+	transactions(account: String, sender: String, receiver: String, blockHash: String, blockHeight: Int, startDate: String, endDate: String, page: Int, limit: Int, cursor: String, skipCount: Boolean): TransactionConnection!
+	validator(id: String!): Validator
+	validators(all: Boolean): [Validator!]!
+	account(id: String!): Account
+	delegatorEpochs(accountId: String, validatorId: String, epoch: String, startDate: String, endDate: String, page: Int, limit: Int, cursor: String, skipCount: Boolean): DelegatorEpochConnection!
+	events(itemId: String, itemType: String, action: String, height: Int, epoch: String, page: Int, limit: Int, cursor: String, skipCount: Boolean): EventConnection!
+}
+
+type PageInfo {
+	page: Int!
+	pages: Int!
+	limit: Int!
+	count: Int!
+	nextCursor: String
+	prevCursor: String
+}
+
+type BlockConnection {
+	pageInfo: PageInfo!
+	records: [Block!]!
+}
+
+type TransactionConnection {
+	pageInfo: PageInfo!
+	records: [Transaction!]!
+}
+
+type ValidatorEpochConnection {
+	pageInfo: PageInfo!
+	records: [ValidatorEpoch!]!
+}
+
+type EventConnection {
+	pageInfo: PageInfo!
+	records: [Event!]!
+}
+
+type DelegatorEpochConnection {
+	pageInfo: PageInfo!
+	records: [DelegatorEpoch!]!
+}
+
+type Block {
+	height: Int!
+	hash: String!
+	time: Time!
+	producer: String!
+	producerValidator: Validator
+	epochId: String!
+	epoch: Epoch
+	gasPrice: String!
+	gasLimit: Float!
+	gasUsed: Float!
+	totalSupply: String!
+	balanceBurnt: String!
+	chunksCount: Int!
+	transactionsCount: Int!
+	approvalsCount: Int!
+	transactions: [Transaction!]!
+	events: [Event!]!
+}
+
+type Epoch {
+	id: String!
+	startHeight: Int!
+	startTime: Time!
+	startBlock: Block
+	endHeight: Int!
+	endTime: Time!
+	endBlock: Block
+	blocksCount: Int!
+	validatorsCount: Int!
+	averageEfficiency: Float!
+	seatPrice: String
+	totalStake: String
+	nakamotoCoefficient: Int
+	totalSupply: String
+	inflation: Float
+	events(page: Int, limit: Int, cursor: String): EventConnection!
+}
+
+type Transaction {
+	hash: String!
+	height: Int!
+	time: Time!
+	blockHash: String!
+	block: Block
+	sender: String!
+	senderAccount: Account
+	receiver: String!
+	receiverAccount: Account
+	amount: String!
+	gasBurnt: String!
+	tokensBurnt: String!
+	fee: String!
+	actions: String!
+	actionsCount: Int!
+	success: Boolean!
+}
+
+type Validator {
+	accountId: String!
+	account: Account
+	startHeight: Int!
+	startTime: Time!
+	lastHeight: Int!
+	lastTime: Time!
+	expectedBlocks: Int!
+	producedBlocks: Int!
+	expectedChunks: Int!
+	producedChunks: Int!
+	active: Boolean!
+	slashed: Boolean!
+	stake: String!
+	efficiency: Float!
+	uptime: Float!
+	rewardFee: Int
+	epochs(page: Int, limit: Int, cursor: String): ValidatorEpochConnection!
+	blocks(page: Int, limit: Int): BlockConnection!
+	events(page: Int, limit: Int, cursor: String): EventConnection!
+	delegators(epoch: String): [DelegatorEpoch!]!
+}
+
+type ValidatorEpoch {
+	accountId: String!
+	validator: Validator
+	epochId: String!
+	epoch: Epoch
+	lastHeight: Int!
+	lastTime: Time!
+	expectedBlocks: Int!
+	producedBlocks: Int!
+	expectedChunks: Int!
+	producedChunks: Int!
+	efficiency: Float!
+	uptime: Float!
+	stakingBalance: String!
+	rewardFee: Int
+}
+
+type DelegatorEpoch {
+	accountId: String!
+	account: Account
+	validatorId: String!
+	validator: Validator
+	epochId: String!
+	epoch: Epoch
+	distributedAtHeight: Int!
+	distributedAtTime: Time!
+	stakedBalance: String!
+	unstakedBalance: String!
+	reward: String
+}
+
+type Account {
+	name: String!
+	startHeight: Int!
+	startTime: Time!
+	lastHeight: Int!
+	lastTime: Time!
+	balance: String!
+	stakingBalance: String!
+	validator: Validator
+	transactions(page: Int, limit: Int, cursor: String): TransactionConnection!
+	delegations(validatorId: String, epoch: String): [DelegatorEpoch!]!
+}
+
+type Event {
+	id: Int!
+	scope: String!
+	action: String!
+	blockHeight: Int!
+	blockTime: Time!
+	block: Block
+	epochId: String!
+	epoch: Epoch
+	itemId: String!
+	itemType: String!
+	metadata: String!
+}
+`
@@ -0,0 +1,13 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchema(t *testing.T) {
+	_, err := graphql.ParseSchema(Schema, &Resolver{})
+	assert.NoError(t, err)
+}
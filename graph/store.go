@@ -0,0 +1,75 @@
+package graph
+
+import (
+	"github.com/figment-networks/near-indexer/model"
+	"github.com/figment-networks/near-indexer/store"
+)
+
+// dataStore contains the store operations used by the resolvers
+type dataStore struct {
+	Accounts      accountsStore
+	Blocks        blocksStore
+	Delegators    delegatorsStore
+	Epochs        epochsStore
+	Events        eventsStore
+	Transactions  transactionsStore
+	ValidatorAggs validatorAggsStore
+}
+
+type accountsStore interface {
+	FindByNames([]string) ([]model.Account, error)
+}
+
+type blocksStore interface {
+	FindByHash(string) (*model.Block, error)
+	FindByHashes([]string) ([]model.Block, error)
+	FindByHeight(uint64) (*model.Block, error)
+	FindByHeights([]uint64) ([]model.Block, error)
+	Search(store.BlocksSearch) (*store.PaginatedResult, error)
+	SearchByProducers([]string, store.Pagination) (map[string]*store.PaginatedResult, error)
+}
+
+type delegatorsStore interface {
+	PaginateDelegatorEpochs(store.DelegatorEpochsSearch, store.Pagination) (*store.PaginatedResult, error)
+	FindByValidators([]string, string) (map[string][]model.DelegatorEpoch, error)
+	FindByAccounts([]string, string, string) (map[string][]model.DelegatorEpoch, error)
+}
+
+type epochsStore interface {
+	FindByID(string) (*model.Epoch, error)
+	FindByIDs([]string) ([]model.Epoch, error)
+	Recent(int) ([]model.Epoch, error)
+}
+
+type eventsStore interface {
+	Search(store.EventsSearch) (*store.PaginatedResult, error)
+	SearchByItems(string, []string, store.Pagination) (map[string]*store.PaginatedResult, error)
+	SearchByEpochs([]string, store.Pagination) (map[string]*store.PaginatedResult, error)
+	SearchByHeights([]uint64, store.Pagination) (map[string]*store.PaginatedResult, error)
+}
+
+type transactionsStore interface {
+	FindByHash(string) (*model.Transaction, error)
+	FindByBlocks([]string) (map[string][]model.Transaction, error)
+	Search(store.TransactionsSearch) (*store.PaginatedResult, error)
+	SearchByAccounts([]string, store.Pagination) (map[string]*store.PaginatedResult, error)
+}
+
+type validatorAggsStore interface {
+	All() ([]model.ValidatorAgg, error)
+	Top() ([]model.ValidatorAgg, error)
+	FindByAccountIDs([]string) ([]model.ValidatorAgg, error)
+	PaginateValidatorEpochsByAccounts([]string, store.Pagination) (map[string]*store.PaginatedResult, error)
+}
+
+func newDataStore(db *store.Store) dataStore {
+	return dataStore{
+		Accounts:      db.Accounts,
+		Blocks:        db.Blocks,
+		Delegators:    db.Delegators,
+		Epochs:        db.Epochs,
+		Events:        db.Events,
+		Transactions:  db.Transactions,
+		ValidatorAggs: db.ValidatorAggs,
+	}
+}
@@ -0,0 +1,540 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/figment-networks/near-indexer/model"
+	"github.com/figment-networks/near-indexer/model/types"
+	"github.com/figment-networks/near-indexer/store"
+)
+
+type pageInfoResolver struct {
+	result *store.PaginatedResult
+}
+
+func (p pageInfoResolver) Page() int32  { return int32(p.result.Page) }
+func (p pageInfoResolver) Pages() int32 { return int32(p.result.Pages) }
+func (p pageInfoResolver) Limit() int32 { return int32(p.result.Limit) }
+func (p pageInfoResolver) Count() int32 { return int32(p.result.Count) }
+
+func (p pageInfoResolver) NextCursor() *string { return optionalString(p.result.NextCursor) }
+func (p pageInfoResolver) PrevCursor() *string { return optionalString(p.result.PrevCursor) }
+
+type blockConnectionResolver struct {
+	r      *Resolver
+	result *store.PaginatedResult
+}
+
+func (c *blockConnectionResolver) PageInfo() pageInfoResolver {
+	return pageInfoResolver{c.result}
+}
+
+func (c *blockConnectionResolver) Records() []*blockResolver {
+	records := c.result.Records.([]model.Block)
+	result := make([]*blockResolver, len(records))
+	for i, b := range records {
+		result[i] = &blockResolver{c.r, b}
+	}
+	return result
+}
+
+type transactionConnectionResolver struct {
+	r      *Resolver
+	result *store.PaginatedResult
+}
+
+func (c *transactionConnectionResolver) PageInfo() pageInfoResolver {
+	return pageInfoResolver{c.result}
+}
+
+func (c *transactionConnectionResolver) Records() []*transactionResolver {
+	records := c.result.Records.([]model.Transaction)
+	result := make([]*transactionResolver, len(records))
+	for i, t := range records {
+		result[i] = &transactionResolver{c.r, t}
+	}
+	return result
+}
+
+type validatorEpochConnectionResolver struct {
+	r      *Resolver
+	result *store.PaginatedResult
+}
+
+func (c *validatorEpochConnectionResolver) PageInfo() pageInfoResolver {
+	return pageInfoResolver{c.result}
+}
+
+func (c *validatorEpochConnectionResolver) Records() []*validatorEpochResolver {
+	records := c.result.Records.([]model.ValidatorEpoch)
+	result := make([]*validatorEpochResolver, len(records))
+	for i, e := range records {
+		result[i] = &validatorEpochResolver{c.r, e}
+	}
+	return result
+}
+
+type eventConnectionResolver struct {
+	r      *Resolver
+	result *store.PaginatedResult
+}
+
+func (c *eventConnectionResolver) PageInfo() pageInfoResolver {
+	return pageInfoResolver{c.result}
+}
+
+func (c *eventConnectionResolver) Records() []*eventResolver {
+	records := c.result.Records.([]model.Event)
+	result := make([]*eventResolver, len(records))
+	for i, e := range records {
+		result[i] = &eventResolver{c.r, e}
+	}
+	return result
+}
+
+type delegatorEpochConnectionResolver struct {
+	r      *Resolver
+	result *store.PaginatedResult
+}
+
+func (c *delegatorEpochConnectionResolver) PageInfo() pageInfoResolver {
+	return pageInfoResolver{c.result}
+}
+
+func (c *delegatorEpochConnectionResolver) Records() []*delegatorEpochResolver {
+	records := c.result.Records.([]model.DelegatorEpoch)
+	result := make([]*delegatorEpochResolver, len(records))
+	for i, d := range records {
+		result[i] = &delegatorEpochResolver{c.r, d}
+	}
+	return result
+}
+
+type blockResolver struct {
+	r *Resolver
+	b model.Block
+}
+
+func (b *blockResolver) Height() int32            { return int32(b.b.ID) }
+func (b *blockResolver) Hash() string             { return b.b.Hash }
+func (b *blockResolver) Time() graphql.Time       { return graphql.Time{Time: b.b.Time} }
+func (b *blockResolver) Producer() string         { return b.b.Producer }
+func (b *blockResolver) EpochID() string          { return b.b.Epoch }
+func (b *blockResolver) GasPrice() string         { return b.b.GasPrice.String() }
+func (b *blockResolver) GasLimit() float64        { return float64(b.b.GasLimit) }
+func (b *blockResolver) GasUsed() float64         { return float64(b.b.GasUsed) }
+func (b *blockResolver) TotalSupply() string      { return b.b.TotalSupply.String() }
+func (b *blockResolver) BalanceBurnt() string     { return b.b.BalanceBurnt.String() }
+func (b *blockResolver) ChunksCount() int32       { return int32(b.b.ChunksCount) }
+func (b *blockResolver) TransactionsCount() int32 { return int32(b.b.TransactionsCount) }
+func (b *blockResolver) ApprovalsCount() int32    { return int32(b.b.ApprovalsCount) }
+
+func (b *blockResolver) ProducerValidator(ctx context.Context) (*validatorResolver, error) {
+	return b.r.validator(ctx, b.b.Producer)
+}
+
+func (b *blockResolver) Epoch(ctx context.Context) (*epochResolver, error) {
+	return b.r.epoch(ctx, b.b.Epoch)
+}
+
+func (b *blockResolver) Transactions(ctx context.Context) ([]*transactionResolver, error) {
+	if b.b.TransactionsCount == 0 {
+		return []*transactionResolver{}, nil
+	}
+
+	data, err := loadRelation(ctx, "block.transactions", nil, b.b.Hash, func(keys []string) (map[string]interface{}, error) {
+		transactions, err := b.r.db.Transactions.FindByBlocks(keys)
+
+		records := make(map[string]interface{}, len(transactions))
+		for hash, t := range transactions {
+			records[hash] = t
+		}
+		return records, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, _ := data.([]model.Transaction)
+	result := make([]*transactionResolver, len(transactions))
+	for i, t := range transactions {
+		result[i] = &transactionResolver{b.r, t}
+	}
+	return result, nil
+}
+
+func (b *blockResolver) Events(ctx context.Context) ([]*eventResolver, error) {
+	var pagination store.Pagination
+
+	result, err := loadPaginatedRelation(ctx, "block.events", pagination, b.b.ID.String(), func(keys []string) (map[string]*store.PaginatedResult, error) {
+		heights := make([]uint64, 0, len(keys))
+		for _, key := range keys {
+			if h, err := strconv.ParseUint(key, 10, 64); err == nil {
+				heights = append(heights, h)
+			}
+		}
+		return b.r.db.Events.SearchByHeights(heights, pagination)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	conn := &eventConnectionResolver{b.r, result}
+	return conn.Records(), nil
+}
+
+type epochResolver struct {
+	r *Resolver
+	e model.Epoch
+}
+
+func (e *epochResolver) ID() string                  { return e.e.ID }
+func (e *epochResolver) StartHeight() int32          { return int32(e.e.StartHeight) }
+func (e *epochResolver) StartTime() graphql.Time     { return graphql.Time{Time: e.e.StartTime} }
+func (e *epochResolver) EndHeight() int32            { return int32(e.e.EndHeight) }
+func (e *epochResolver) EndTime() graphql.Time       { return graphql.Time{Time: e.e.EndTime} }
+func (e *epochResolver) BlocksCount() int32          { return int32(e.e.BlocksCount) }
+func (e *epochResolver) ValidatorsCount() int32      { return int32(e.e.ValidatorsCount) }
+func (e *epochResolver) AverageEfficiency() float64  { return e.e.AverageEfficiency }
+func (e *epochResolver) SeatPrice() *string          { return optionalAmount(e.e.SeatPrice) }
+func (e *epochResolver) TotalStake() *string         { return optionalAmount(e.e.TotalStake) }
+func (e *epochResolver) NakamotoCoefficient() *int32 { return optionalInt(e.e.NakamotoCoefficient) }
+func (e *epochResolver) TotalSupply() *string        { return optionalAmount(e.e.TotalSupply) }
+func (e *epochResolver) Inflation() *float64         { return e.e.Inflation }
+
+func (e *epochResolver) StartBlock(ctx context.Context) (*blockResolver, error) {
+	return e.r.blockByHeight(ctx, e.e.StartHeight)
+}
+
+func (e *epochResolver) EndBlock(ctx context.Context) (*blockResolver, error) {
+	return e.r.blockByHeight(ctx, e.e.EndHeight)
+}
+
+func (e *epochResolver) Events(ctx context.Context, args paginationArgs) (*eventConnectionResolver, error) {
+	pagination := args.pagination()
+
+	result, err := loadPaginatedRelation(ctx, "epoch.events", pagination, e.e.ID, func(keys []string) (map[string]*store.PaginatedResult, error) {
+		return e.r.db.Events.SearchByEpochs(keys, pagination)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &eventConnectionResolver{e.r, result}, nil
+}
+
+type transactionResolver struct {
+	r *Resolver
+	t model.Transaction
+}
+
+func (t *transactionResolver) Hash() string        { return t.t.Hash }
+func (t *transactionResolver) Height() int32       { return int32(t.t.Height) }
+func (t *transactionResolver) Time() graphql.Time  { return graphql.Time{Time: t.t.Time} }
+func (t *transactionResolver) BlockHash() string   { return t.t.BlockHash }
+func (t *transactionResolver) Sender() string      { return t.t.Sender }
+func (t *transactionResolver) Receiver() string    { return t.t.Receiver }
+func (t *transactionResolver) Amount() string      { return t.t.Amount.String() }
+func (t *transactionResolver) GasBurnt() string    { return t.t.GasBurnt }
+func (t *transactionResolver) TokensBurnt() string { return t.t.TokensBurnt.String() }
+func (t *transactionResolver) Fee() string         { return t.t.Fee.String() }
+func (t *transactionResolver) Actions() string     { return rawJSON(t.t.Actions) }
+func (t *transactionResolver) ActionsCount() int32 { return int32(t.t.ActionsCount) }
+func (t *transactionResolver) Success() bool       { return t.t.Success }
+
+func (t *transactionResolver) Block(ctx context.Context) (*blockResolver, error) {
+	block, err := loadBlockByHash(ctx, t.t.BlockHash)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	return &blockResolver{t.r, *block}, nil
+}
+
+func (t *transactionResolver) SenderAccount(ctx context.Context) (*accountResolver, error) {
+	return t.r.account(ctx, t.t.Sender)
+}
+
+func (t *transactionResolver) ReceiverAccount(ctx context.Context) (*accountResolver, error) {
+	return t.r.account(ctx, t.t.Receiver)
+}
+
+type validatorResolver struct {
+	r *Resolver
+	v model.ValidatorAgg
+}
+
+func (v *validatorResolver) AccountID() string       { return v.v.AccountID }
+func (v *validatorResolver) StartHeight() int32      { return int32(v.v.StartHeight) }
+func (v *validatorResolver) StartTime() graphql.Time { return graphql.Time{Time: v.v.StartTime} }
+func (v *validatorResolver) LastHeight() int32       { return int32(v.v.LastHeight) }
+func (v *validatorResolver) LastTime() graphql.Time  { return graphql.Time{Time: v.v.LastTime} }
+func (v *validatorResolver) ExpectedBlocks() int32   { return int32(v.v.ExpectedBlocks) }
+func (v *validatorResolver) ProducedBlocks() int32   { return int32(v.v.ProducedBlocks) }
+func (v *validatorResolver) ExpectedChunks() int32   { return int32(v.v.ExpectedChunks) }
+func (v *validatorResolver) ProducedChunks() int32   { return int32(v.v.ProducedChunks) }
+func (v *validatorResolver) Active() bool            { return v.v.Active }
+func (v *validatorResolver) Slashed() bool           { return v.v.Slashed }
+func (v *validatorResolver) Stake() string           { return v.v.Stake.String() }
+func (v *validatorResolver) Efficiency() float64     { return v.v.Efficiency }
+func (v *validatorResolver) Uptime() float64         { return v.v.Uptime }
+func (v *validatorResolver) RewardFee() *int32       { return optionalInt(v.v.RewardFee) }
+
+func (v *validatorResolver) Account(ctx context.Context) (*accountResolver, error) {
+	return v.r.account(ctx, v.v.AccountID)
+}
+
+func (v *validatorResolver) Epochs(ctx context.Context, args paginationArgs) (*validatorEpochConnectionResolver, error) {
+	pagination := args.pagination()
+
+	result, err := loadPaginatedRelation(ctx, "validator.epochs", pagination, v.v.AccountID, func(keys []string) (map[string]*store.PaginatedResult, error) {
+		return v.r.db.ValidatorAggs.PaginateValidatorEpochsByAccounts(keys, pagination)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &validatorEpochConnectionResolver{v.r, result}, nil
+}
+
+func (v *validatorResolver) Blocks(ctx context.Context, args struct {
+	Page  *int32
+	Limit *int32
+}) (*blockConnectionResolver, error) {
+	pagination := paginationArgs{Page: args.Page, Limit: args.Limit}.pagination()
+
+	result, err := loadPaginatedRelation(ctx, "validator.blocks", pagination, v.v.AccountID, func(keys []string) (map[string]*store.PaginatedResult, error) {
+		return v.r.db.Blocks.SearchByProducers(keys, pagination)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &blockConnectionResolver{v.r, result}, nil
+}
+
+func (v *validatorResolver) Events(ctx context.Context, args paginationArgs) (*eventConnectionResolver, error) {
+	pagination := args.pagination()
+
+	result, err := loadPaginatedRelation(ctx, "validator.events", pagination, v.v.AccountID, func(keys []string) (map[string]*store.PaginatedResult, error) {
+		return v.r.db.Events.SearchByItems(model.ItemTypeValidator, keys, pagination)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &eventConnectionResolver{v.r, result}, nil
+}
+
+func (v *validatorResolver) Delegators(ctx context.Context, args struct{ Epoch *string }) ([]*delegatorEpochResolver, error) {
+	epoch := stringArg(args.Epoch)
+
+	return v.r.delegatorEpochsRelation(ctx, "validator.delegators", epoch, v.v.AccountID, func(keys []string) (map[string][]model.DelegatorEpoch, error) {
+		return v.r.db.Delegators.FindByValidators(keys, epoch)
+	})
+}
+
+type validatorEpochResolver struct {
+	r *Resolver
+	e model.ValidatorEpoch
+}
+
+func (e *validatorEpochResolver) AccountID() string      { return e.e.AccountID }
+func (e *validatorEpochResolver) EpochID() string        { return e.e.Epoch }
+func (e *validatorEpochResolver) LastHeight() int32      { return int32(e.e.LastHeight) }
+func (e *validatorEpochResolver) LastTime() graphql.Time { return graphql.Time{Time: e.e.LastTime} }
+func (e *validatorEpochResolver) ExpectedBlocks() int32  { return int32(e.e.ExpectedBlocks) }
+func (e *validatorEpochResolver) ProducedBlocks() int32  { return int32(e.e.ProducedBlocks) }
+func (e *validatorEpochResolver) ExpectedChunks() int32  { return int32(e.e.ExpectedChunks) }
+func (e *validatorEpochResolver) ProducedChunks() int32  { return int32(e.e.ProducedChunks) }
+func (e *validatorEpochResolver) Efficiency() float64    { return e.e.Efficiency }
+func (e *validatorEpochResolver) Uptime() float64        { return e.e.Uptime }
+func (e *validatorEpochResolver) StakingBalance() string { return e.e.StakingBalance.String() }
+func (e *validatorEpochResolver) RewardFee() *int32      { return optionalInt(e.e.RewardFee) }
+
+func (e *validatorEpochResolver) Validator(ctx context.Context) (*validatorResolver, error) {
+	return e.r.validator(ctx, e.e.AccountID)
+}
+
+func (e *validatorEpochResolver) Epoch(ctx context.Context) (*epochResolver, error) {
+	return e.r.epoch(ctx, e.e.Epoch)
+}
+
+type delegatorEpochResolver struct {
+	r *Resolver
+	d model.DelegatorEpoch
+}
+
+func (d *delegatorEpochResolver) AccountID() string          { return d.d.AccountID }
+func (d *delegatorEpochResolver) ValidatorID() string        { return d.d.ValidatorID }
+func (d *delegatorEpochResolver) EpochID() string            { return d.d.Epoch }
+func (d *delegatorEpochResolver) DistributedAtHeight() int32 { return int32(d.d.DistributedAtHeight) }
+func (d *delegatorEpochResolver) DistributedAtTime() graphql.Time {
+	return graphql.Time{Time: d.d.DistributedAtTime}
+}
+func (d *delegatorEpochResolver) StakedBalance() string   { return d.d.StakedBalance.String() }
+func (d *delegatorEpochResolver) UnstakedBalance() string { return d.d.UnstakedBalance.String() }
+func (d *delegatorEpochResolver) Reward() *string         { return optionalAmount(d.d.Reward) }
+
+func (d *delegatorEpochResolver) Account(ctx context.Context) (*accountResolver, error) {
+	return d.r.account(ctx, d.d.AccountID)
+}
+
+func (d *delegatorEpochResolver) Validator(ctx context.Context) (*validatorResolver, error) {
+	return d.r.validator(ctx, d.d.ValidatorID)
+}
+
+func (d *delegatorEpochResolver) Epoch(ctx context.Context) (*epochResolver, error) {
+	return d.r.epoch(ctx, d.d.Epoch)
+}
+
+type accountResolver struct {
+	r *Resolver
+	a model.Account
+}
+
+func (a *accountResolver) Name() string            { return a.a.Name }
+func (a *accountResolver) StartHeight() int32      { return int32(a.a.StartHeight) }
+func (a *accountResolver) StartTime() graphql.Time { return graphql.Time{Time: a.a.StartTime} }
+func (a *accountResolver) LastHeight() int32       { return int32(a.a.LastHeight) }
+func (a *accountResolver) LastTime() graphql.Time  { return graphql.Time{Time: a.a.LastTime} }
+func (a *accountResolver) Balance() string         { return a.a.Balance.String() }
+func (a *accountResolver) StakingBalance() string  { return a.a.StakingBalance.String() }
+
+func (a *accountResolver) Validator(ctx context.Context) (*validatorResolver, error) {
+	return a.r.validator(ctx, a.a.Name)
+}
+
+func (a *accountResolver) Transactions(ctx context.Context, args paginationArgs) (*transactionConnectionResolver, error) {
+	pagination := args.pagination()
+
+	result, err := loadPaginatedRelation(ctx, "account.transactions", pagination, a.a.Name, func(keys []string) (map[string]*store.PaginatedResult, error) {
+		return a.r.db.Transactions.SearchByAccounts(keys, pagination)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &transactionConnectionResolver{a.r, result}, nil
+}
+
+func (a *accountResolver) Delegations(ctx context.Context, args struct {
+	ValidatorID *string
+	Epoch       *string
+}) ([]*delegatorEpochResolver, error) {
+	validatorID := stringArg(args.ValidatorID)
+	epoch := stringArg(args.Epoch)
+
+	return a.r.delegatorEpochsRelation(ctx, "account.delegations", validatorID+":"+epoch, a.a.Name, func(keys []string) (map[string][]model.DelegatorEpoch, error) {
+		return a.r.db.Delegators.FindByAccounts(keys, validatorID, epoch)
+	})
+}
+
+type eventResolver struct {
+	r *Resolver
+	e model.Event
+}
+
+func (e *eventResolver) ID() int32               { return int32(e.e.ID) }
+func (e *eventResolver) Scope() string           { return e.e.Scope }
+func (e *eventResolver) Action() string          { return e.e.Action }
+func (e *eventResolver) BlockHeight() int32      { return int32(e.e.BlockHeight) }
+func (e *eventResolver) BlockTime() graphql.Time { return graphql.Time{Time: e.e.BlockTime} }
+func (e *eventResolver) EpochID() string         { return e.e.Epoch }
+func (e *eventResolver) ItemID() string          { return e.e.ItemID }
+func (e *eventResolver) ItemType() string        { return e.e.ItemType }
+
+func (e *eventResolver) Metadata() (string, error) {
+	data, err := json.Marshal(e.e.Metadata)
+	return string(data), err
+}
+
+func (e *eventResolver) Block(ctx context.Context) (*blockResolver, error) {
+	return e.r.blockByHeight(ctx, e.e.BlockHeight)
+}
+
+func (e *eventResolver) Epoch(ctx context.Context) (*epochResolver, error) {
+	return e.r.epoch(ctx, e.e.Epoch)
+}
+
+func (r *Resolver) delegatorEpochsRelation(ctx context.Context, name string, args interface{}, key string, fetch func(keys []string) (map[string][]model.DelegatorEpoch, error)) ([]*delegatorEpochResolver, error) {
+	data, err := loadRelation(ctx, name, args, key, func(keys []string) (map[string]interface{}, error) {
+		delegatorEpochs, err := fetch(keys)
+
+		records := make(map[string]interface{}, len(delegatorEpochs))
+		for k, d := range delegatorEpochs {
+			records[k] = d
+		}
+		return records, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	records, _ := data.([]model.DelegatorEpoch)
+	result := make([]*delegatorEpochResolver, len(records))
+	for i, d := range records {
+		result[i] = &delegatorEpochResolver{r, d}
+	}
+	return result, nil
+}
+
+func (r *Resolver) blockByHeight(ctx context.Context, height uint64) (*blockResolver, error) {
+	block, err := loadBlockByHeight(ctx, height)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	return &blockResolver{r, *block}, nil
+}
+
+func (r *Resolver) epoch(ctx context.Context, id string) (*epochResolver, error) {
+	epoch, err := loadEpoch(ctx, id)
+	if err != nil || epoch == nil {
+		return nil, err
+	}
+	return &epochResolver{r, *epoch}, nil
+}
+
+func (r *Resolver) validator(ctx context.Context, id string) (*validatorResolver, error) {
+	validator, err := loadValidator(ctx, id)
+	if err != nil || validator == nil {
+		return nil, err
+	}
+	return &validatorResolver{r, *validator}, nil
+}
+
+func (r *Resolver) account(ctx context.Context, name string) (*accountResolver, error) {
+	account, err := loadAccount(ctx, name)
+	if err != nil || account == nil {
+		return nil, err
+	}
+	return &accountResolver{r, *account}, nil
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func optionalAmount(a types.Amount) *string {
+	if a.Int == nil {
+		return nil
+	}
+	s := a.String()
+	return &s
+}
+
+func optionalInt(n *int) *int32 {
+	if n == nil {
+		return nil
+	}
+	v := int32(*n)
+	return &v
+}
+
+func rawJSON(data json.RawMessage) string {
+	if len(data) == 0 {
+		return "null"
+	}
+	return string(data)
+}
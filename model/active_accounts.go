@@ -0,0 +1,10 @@
+package model
+
+// ActiveAccountsSummary contains the number of active accounts for a time interval
+type ActiveAccountsSummary struct {
+	Interval         string `json:"interval"`
+	SignersCount     int    `json:"signers_count"`
+	ReceiversCount   int    `json:"receivers_count"`
+	AccountsCount    int    `json:"accounts_count"`
+	NewAccountsCount int    `json:"new_accounts_count"`
+}
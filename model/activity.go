@@ -0,0 +1,39 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/figment-networks/near-indexer/model/types"
+)
+
+const (
+	ActivityTransactionSigned   = "transaction_signed"
+	ActivityTransactionReceived = "transaction_received"
+	ActivityReceipt             = "receipt"
+	ActivityStaking             = "staking"
+	ActivityReward              = "reward"
+	ActivityValidatorEvent      = "validator_event"
+)
+
+var (
+	// ActivityTypes lists all account activity types
+	ActivityTypes = []string{
+		ActivityTransactionSigned,
+		ActivityTransactionReceived,
+		ActivityReceipt,
+		ActivityStaking,
+		ActivityReward,
+		ActivityValidatorEvent,
+	}
+)
+
+// Activity is a single item of the account activity feed. Data contains the
+// source record: a transaction, receipt, delegator epoch or event.
+type Activity struct {
+	ID     int64           `json:"-"`
+	Type   string          `json:"type"`
+	Height types.Height    `json:"height"`
+	Time   time.Time       `json:"time"`
+	Data   json.RawMessage `json:"data"`
+}
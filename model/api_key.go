@@ -0,0 +1,105 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+const (
+	// APIKeyPrefixLength is the number of key characters stored in plain text
+	APIKeyPrefixLength = 8
+
+	apiKeyBytes = 24
+)
+
+var (
+	errAPIKeyNameRequired   = errors.New("name is required")
+	errAPIKeyRateInvalid    = errors.New("rate limit is invalid")
+	errAPIKeyQuotaInvalid   = errors.New("daily quota is invalid")
+	errAPIKeyHashRequired   = errors.New("key hash is required")
+	errAPIKeyPrefixRequired = errors.New("key prefix is required")
+)
+
+// APIKey grants access to the API. Only the SHA256 digest of the key is stored,
+// the key itself is only available when it's generated.
+type APIKey struct {
+	Model
+
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	KeyHash    string     `json:"-"`
+	RateLimit  float64    `json:"rate_limit"`
+	RateBurst  int        `json:"rate_burst"`
+	DailyQuota int64      `json:"daily_quota"`
+	Admin      bool       `json:"admin"`
+	Active     bool       `json:"active"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+}
+
+// APIKeyUsage is a daily requests counter of an API key
+type APIKeyUsage struct {
+	APIKeyID int64     `json:"api_key_id"`
+	Day      time.Time `json:"day"`
+	Requests int64     `json:"requests"`
+	Rejected int64     `json:"rejected"`
+}
+
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+func (APIKeyUsage) TableName() string {
+	return "api_key_usage"
+}
+
+// NewAPIKey returns a new active API key along with its plain text value
+func NewAPIKey(name string) (*APIKey, string, error) {
+	buf := make([]byte, apiKeyBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, "", err
+	}
+	key := hex.EncodeToString(buf)
+
+	apiKey := &APIKey{
+		Name:    name,
+		Prefix:  key[:APIKeyPrefixLength],
+		KeyHash: HashAPIKey(key),
+		Active:  true,
+	}
+
+	return apiKey, key, nil
+}
+
+// HashAPIKey returns the hex encoded SHA256 digest of the key
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Validate returns an error if API key is invalid
+func (k APIKey) Validate() error {
+	if k.Name == "" {
+		return errAPIKeyNameRequired
+	}
+	if k.Prefix == "" {
+		return errAPIKeyPrefixRequired
+	}
+	if k.KeyHash == "" {
+		return errAPIKeyHashRequired
+	}
+	if k.RateLimit < 0 || k.RateBurst < 0 {
+		return errAPIKeyRateInvalid
+	}
+	if k.DailyQuota < 0 {
+		return errAPIKeyQuotaInvalid
+	}
+	return nil
+}
+
+// QuotaExceeded returns true if the number of requests is over the daily quota
+func (k APIKey) QuotaExceeded(requests int64) bool {
+	return k.DailyQuota > 0 && requests > k.DailyQuota
+}
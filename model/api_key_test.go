@@ -0,0 +1,56 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	k, key, err := NewAPIKey("explorer")
+	assert.NoError(t, err)
+	assert.Len(t, key, 48)
+	assert.Equal(t, "explorer", k.Name)
+	assert.Equal(t, key[:APIKeyPrefixLength], k.Prefix)
+	assert.Equal(t, HashAPIKey(key), k.KeyHash)
+	assert.True(t, k.Active)
+	assert.NoError(t, k.Validate())
+
+	_, other, err := NewAPIKey("explorer")
+	assert.NoError(t, err)
+	assert.NotEqual(t, key, other)
+}
+
+func TestHashAPIKey(t *testing.T) {
+	assert.Equal(t,
+		"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		HashAPIKey("test"),
+	)
+}
+
+func TestAPIKeyValidate(t *testing.T) {
+	valid := APIKey{Name: "test", Prefix: "abcd", KeyHash: "hash"}
+	assert.NoError(t, valid.Validate())
+
+	k := valid
+	k.Name = ""
+	assert.Equal(t, errAPIKeyNameRequired, k.Validate())
+
+	k = valid
+	k.KeyHash = ""
+	assert.Equal(t, errAPIKeyHashRequired, k.Validate())
+
+	k = valid
+	k.RateLimit = -1
+	assert.Equal(t, errAPIKeyRateInvalid, k.Validate())
+
+	k = valid
+	k.DailyQuota = -1
+	assert.Equal(t, errAPIKeyQuotaInvalid, k.Validate())
+}
+
+func TestAPIKeyQuotaExceeded(t *testing.T) {
+	assert.False(t, APIKey{}.QuotaExceeded(1000000))
+	assert.False(t, APIKey{DailyQuota: 100}.QuotaExceeded(100))
+	assert.True(t, APIKey{DailyQuota: 100}.QuotaExceeded(101))
+}
@@ -25,12 +25,24 @@ type Block struct {
 	GasLimit          uint         `json:"gas_allowed"`
 	GasUsed           uint         `json:"gas_used"`
 	TotalSupply       types.Amount `json:"total_supply"`
+	BalanceBurnt      types.Amount `json:"balance_burnt"`
 	ChunksCount       int          `json:"chunks_count"`
 	TransactionsCount int          `json:"transactions_count"`
 	ApprovalsCount    int          `json:"approvals_count"`
 	CreatedAt         time.Time    `json:"created_at"`
 }
 
+// BlockLink references an adjacent block
+type BlockLink struct {
+	Height types.Height `json:"height"`
+	Hash   string       `json:"hash"`
+}
+
+// Link returns a reference to the block
+func (b Block) Link() *BlockLink {
+	return &BlockLink{Height: b.ID, Hash: b.Hash}
+}
+
 // Validate returns an error if block data is invalid
 func (b Block) Validate() error {
 	if !b.ID.Valid() {
@@ -22,3 +22,11 @@ type DelegatorEpoch struct {
 func (DelegatorEpoch) TableName() string {
 	return "delegator_epochs"
 }
+
+// DelegatorsSummary contains aggregated delegation stats for a validator epoch
+type DelegatorsSummary struct {
+	DelegatorsCount    int          `json:"delegators_count"`
+	TotalStaked        types.Amount `json:"total_staked"`
+	Top10Staked        types.Amount `json:"top10_staked"`
+	Top10Concentration float64      `json:"top10_concentration"`
+}
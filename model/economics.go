@@ -0,0 +1,18 @@
+package model
+
+import (
+	"github.com/figment-networks/near-indexer/model/types"
+)
+
+// EconomicsSummary contains network supply changes for a time interval
+type EconomicsSummary struct {
+	Interval         string       `json:"interval"`
+	EpochsCount      int          `json:"epochs_count"`
+	StartSupply      types.Amount `json:"start_supply"`
+	EndSupply        types.Amount `json:"end_supply"`
+	TotalSupplyDelta types.Amount `json:"total_supply_delta"`
+	Minted           types.Amount `json:"minted"`
+	BalanceBurnt     types.Amount `json:"balance_burnt"`
+	TreasuryReward   types.Amount `json:"treasury_reward"`
+	Inflation        float64      `json:"inflation"`
+}
@@ -3,23 +3,39 @@ package model
 import (
 	"errors"
 	"time"
+
+	"github.com/figment-networks/near-indexer/model/types"
 )
 
 type Epoch struct {
-	ID                string    `json:"id"`
-	StartHeight       uint64    `json:"start_height"`
-	StartTime         time.Time `json:"start_time"`
-	EndHeight         uint64    `json:"end_height"`
-	EndTime           time.Time `json:"end_time"`
-	BlocksCount       uint      `json:"blocks_count"`
-	ValidatorsCount   uint      `json:"validators_count"`
-	AverageEfficiency float64   `json:"average_efficiency"`
+	ID                  string       `json:"id"`
+	StartHeight         uint64       `json:"start_height"`
+	StartTime           time.Time    `json:"start_time"`
+	EndHeight           uint64       `json:"end_height"`
+	EndTime             time.Time    `json:"end_time"`
+	BlocksCount         uint         `json:"blocks_count"`
+	ValidatorsCount     uint         `json:"validators_count"`
+	AverageEfficiency   float64      `json:"average_efficiency"`
+	SeatPrice           types.Amount `json:"seat_price"`
+	TotalStake          types.Amount `json:"total_stake"`
+	NakamotoCoefficient *int         `json:"nakamoto_coefficient"`
+	TotalSupply         types.Amount `json:"total_supply"`
+	TotalSupplyDelta    types.Amount `json:"total_supply_delta"`
+	BalanceBurnt        types.Amount `json:"balance_burnt"`
+	Minted              types.Amount `json:"minted"`
+	TreasuryReward      types.Amount `json:"treasury_reward"`
+	Inflation           *float64     `json:"inflation"`
 }
 
 func (Epoch) TableName() string {
 	return "epochs"
 }
 
+// HasStakeStats returns true if epoch contains validator stake data
+func (e Epoch) HasStakeStats() bool {
+	return e.SeatPrice.Int != nil
+}
+
 func (e Epoch) Validate() error {
 	if e.ID == "" {
 		return errors.New("uuid is not provided")
@@ -10,10 +10,12 @@ import (
 const (
 	ScopeStaking = "staking"
 
-	ActionValidatorAdded   = "joined_active_set"
-	ActionValidatorRemoved = "left_active_set"
-	ActionValidatorKicked  = "kicked"
-	ActionBalanceChanged   = "balance_changed"
+	ActionValidatorAdded    = "joined_active_set"
+	ActionValidatorRemoved  = "left_active_set"
+	ActionValidatorKicked   = "kicked"
+	ActionBalanceChanged    = "balance_changed"
+	ActionProposalSubmitted = "proposal_submitted"
+	ActionWillJoinNextEpoch = "will_join_next_epoch"
 
 	ItemTypeValidator = "validator"
 )
@@ -11,6 +11,8 @@ import (
 func Block(block *near.Block) (*model.Block, error) {
 	h := block.Header
 
+	gasUsed, gasLimit := blockGas(block)
+
 	record := &model.Block{
 		ID:             types.Height(h.Height),
 		Hash:           h.Hash,
@@ -21,7 +23,37 @@ func Block(block *near.Block) (*model.Block, error) {
 		ChunksCount:    h.ChunksIncluded,
 		ApprovalsCount: len(block.Header.Approvals),
 		GasPrice:       types.NewAmount(h.GasPrice),
+		GasUsed:        gasUsed,
+		GasLimit:       gasLimit,
+		BalanceBurnt:   balanceBurnt(block),
 	}
 
 	return record, record.Validate()
 }
+
+// balanceBurnt returns the total amount burnt by chunks included in the block
+func balanceBurnt(block *near.Block) types.Amount {
+	total := types.NewAmount("0")
+
+	for _, chunk := range block.Chunks {
+		// Skip chunk headers carried over from previous blocks
+		if chunk.HeightIncluded != block.Header.Height {
+			continue
+		}
+		total = total.Add(types.NewAmount(chunk.BalanceBurnt))
+	}
+
+	return total
+}
+
+// blockGas returns the total gas used and gas limit of chunks included in the block
+func blockGas(block *near.Block) (used uint, limit uint) {
+	for _, chunk := range block.Chunks {
+		if chunk.HeightIncluded != block.Header.Height {
+			continue
+		}
+		used += uint(chunk.GasUsed)
+		limit += uint(chunk.GasLimit)
+	}
+	return
+}
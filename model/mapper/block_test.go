@@ -0,0 +1,36 @@
+package mapper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/figment-networks/near-indexer/near"
+)
+
+func TestBlock(t *testing.T) {
+	block := &near.Block{
+		Author: "producer",
+		Header: near.BlockHeader{
+			Height:      100,
+			Hash:        "hash",
+			EpochID:     "epoch",
+			Timestamp:   1596166782911378000,
+			TotalSupply: "1000",
+			GasPrice:    "5000",
+		},
+		Chunks: []near.BlockChunk{
+			{HeightIncluded: 100, BalanceBurnt: "10", GasUsed: 100, GasLimit: 1000},
+			{HeightIncluded: 99, BalanceBurnt: "20", GasUsed: 200, GasLimit: 1000},
+			{HeightIncluded: 100, BalanceBurnt: "5", GasUsed: 300, GasLimit: 1000},
+		},
+	}
+
+	record, err := Block(block)
+	assert.NoError(t, err)
+	assert.Equal(t, "producer", record.Producer)
+	assert.Equal(t, "1000", record.TotalSupply.String())
+	assert.Equal(t, "15", record.BalanceBurnt.String())
+	assert.Equal(t, uint(400), record.GasUsed)
+	assert.Equal(t, uint(2000), record.GasLimit)
+}
@@ -0,0 +1,48 @@
+package mapper
+
+import (
+	"math/big"
+
+	"github.com/figment-networks/near-indexer/model"
+	"github.com/figment-networks/near-indexer/model/types"
+	"github.com/figment-networks/near-indexer/model/util"
+	"github.com/figment-networks/near-indexer/near"
+)
+
+// Epoch constructs a new epoch record from the block and its epoch validators
+func Epoch(block *near.Block, validators []near.Validator) (*model.Epoch, error) {
+	h := block.Header
+	time := util.ParseTime(h.Timestamp)
+
+	epoch := &model.Epoch{
+		ID:          h.EpochID,
+		StartTime:   time,
+		StartHeight: h.Height,
+		EndTime:     time,
+		EndHeight:   h.Height,
+	}
+
+	if len(validators) > 0 {
+		stakes := make([]*big.Int, len(validators))
+		seatPrice := types.NewAmount(validators[0].Stake)
+		totalStake := types.NewAmount("0")
+
+		for i, v := range validators {
+			stake := types.NewAmount(v.Stake)
+			stakes[i] = stake.Int
+
+			totalStake = totalStake.Add(stake)
+			if stake.Compare(seatPrice) < 0 {
+				seatPrice = stake
+			}
+		}
+
+		nakamoto := util.NakamotoCoefficient(stakes)
+
+		epoch.SeatPrice = seatPrice
+		epoch.TotalStake = totalStake
+		epoch.NakamotoCoefficient = &nakamoto
+	}
+
+	return epoch, epoch.Validate()
+}
@@ -0,0 +1,37 @@
+package mapper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/figment-networks/near-indexer/near"
+)
+
+func TestEpoch(t *testing.T) {
+	block := &near.Block{
+		Header: near.BlockHeader{
+			Height:    10885359,
+			Timestamp: 1596166782911378000,
+			EpochID:   "epoch",
+		},
+	}
+
+	epoch, err := Epoch(block, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "epoch", epoch.ID)
+	assert.Equal(t, uint64(10885359), epoch.StartHeight)
+	assert.False(t, epoch.HasStakeStats())
+	assert.Nil(t, epoch.NakamotoCoefficient)
+
+	epoch, err = Epoch(block, []near.Validator{
+		{AccountID: "a", Stake: "500"},
+		{AccountID: "b", Stake: "300"},
+		{AccountID: "c", Stake: "200"},
+	})
+	assert.NoError(t, err)
+	assert.True(t, epoch.HasStakeStats())
+	assert.Equal(t, "200", epoch.SeatPrice.String())
+	assert.Equal(t, "1000", epoch.TotalStake.String())
+	assert.Equal(t, 1, *epoch.NakamotoCoefficient)
+}
@@ -105,3 +105,42 @@ func ValidatorStakingBalanceChangeEvent(block *near.Block, validator *near.Valid
 
 	return event, nil
 }
+
+// ProposalSubmittedEvent generates an event for a validator stake proposal
+func ProposalSubmittedEvent(block *near.Block, proposal *model.ValidatorProposal) (*model.Event, error) {
+	event := &model.Event{
+		Scope:       model.ScopeStaking,
+		Action:      model.ActionProposalSubmitted,
+		BlockHeight: block.Header.Height,
+		BlockTime:   util.ParseTime(block.Header.Timestamp),
+		Epoch:       block.Header.EpochID,
+		ItemID:      proposal.AccountID,
+		ItemType:    model.ItemTypeValidator,
+		Metadata: types.Map{
+			"stake": proposal.Stake.String(),
+		},
+		CreatedAt: time.Now(),
+	}
+
+	return event, event.Validate()
+}
+
+// ValidatorJoinNextEpochEvent generates an event for validator joining the next epoch set
+func ValidatorJoinNextEpochEvent(block *near.Block, validator *near.Validator) (*model.Event, error) {
+	event := &model.Event{
+		Scope:       model.ScopeStaking,
+		Action:      model.ActionWillJoinNextEpoch,
+		BlockHeight: block.Header.Height,
+		BlockTime:   util.ParseTime(block.Header.Timestamp),
+		Epoch:       block.Header.EpochID,
+		ItemID:      validator.AccountID,
+		ItemType:    model.ItemTypeValidator,
+		Metadata: types.Map{
+			"stake":      validator.Stake,
+			"next_epoch": block.Header.NextEpochID,
+		},
+		CreatedAt: time.Now(),
+	}
+
+	return event, event.Validate()
+}
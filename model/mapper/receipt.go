@@ -0,0 +1,55 @@
+package mapper
+
+import (
+	"fmt"
+
+	"github.com/figment-networks/near-indexer/model"
+	"github.com/figment-networks/near-indexer/model/types"
+	"github.com/figment-networks/near-indexer/model/util"
+	"github.com/figment-networks/near-indexer/near"
+)
+
+// Receipts constructs receipts executed on accounts other than the transaction
+// signer and receiver. Gas refunds to the signer and the receipts executed on the
+// receiver are already covered by the transaction itself.
+// Receipts are stamped with the block they were executed in, found by hash in
+// executedIn, and fall back to the transaction block when it's not available.
+func Receipts(block *near.Block, executedIn map[string]near.BlockHeader, details []near.TransactionDetails) ([]model.Receipt, error) {
+	result := []model.Receipt{}
+
+	for _, input := range details {
+		tx := input.Transaction
+
+		for _, outcome := range input.ReceiptsOutcome {
+			executor := outcome.Outcome.ExecutorID
+			if executor == "" || executor == tx.SignerID || executor == tx.ReceiverID {
+				continue
+			}
+
+			header, ok := executedIn[outcome.BlockHash]
+			if !ok {
+				header = block.Header
+			}
+
+			receipt := model.Receipt{
+				ReceiptID:       outcome.ID,
+				TransactionHash: tx.Hash,
+				Height:          types.Height(header.Height),
+				Time:            util.ParseTime(header.Timestamp),
+				Signer:          tx.SignerID,
+				Receiver:        executor,
+				GasBurnt:        fmt.Sprintf("%v", outcome.Outcome.GasBurnt),
+				TokensBurnt:     types.NewAmount(outcome.Outcome.TokensBurnt),
+				Success:         outcome.Outcome.Status.Failure == nil,
+			}
+
+			if err := receipt.Validate(); err != nil {
+				return nil, fmt.Errorf("receipt (%s) is invalid: %w", outcome.ID, err)
+			}
+
+			result = append(result, receipt)
+		}
+	}
+
+	return result, nil
+}
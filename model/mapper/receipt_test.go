@@ -0,0 +1,72 @@
+package mapper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/figment-networks/near-indexer/near"
+)
+
+func TestReceipts(t *testing.T) {
+	block := &near.Block{
+		Header: near.BlockHeader{
+			Hash:      "blockhash",
+			Height:    100,
+			Timestamp: 1596166782911378000,
+		},
+	}
+
+	executedIn := map[string]near.BlockHeader{
+		"blockhash": block.Header,
+		"nexthash": {
+			Hash:      "nexthash",
+			Height:    101,
+			Timestamp: 1596166783911378000,
+		},
+	}
+
+	input := loadTransactionFixture(t, "transaction_deposit.json")
+	input.ReceiptsOutcome[0].Outcome.ExecutorID = input.Transaction.ReceiverID
+	input.ReceiptsOutcome = append(input.ReceiptsOutcome,
+		near.ReceiptsOutcome{
+			BlockHash: "nexthash",
+			ID:        "receipt1",
+			Outcome: near.Outcome{
+				ExecutorID:  "pool.betanet",
+				GasBurnt:    100,
+				TokensBurnt: "10",
+			},
+		},
+		near.ReceiptsOutcome{
+			BlockHash: "unknownhash",
+			ID:        "receipt2",
+			Outcome: near.Outcome{
+				ExecutorID: "other.betanet",
+				Status:     near.Status{Failure: map[string]interface{}{"ActionError": nil}},
+			},
+		},
+		near.ReceiptsOutcome{
+			ID:      "refund",
+			Outcome: near.Outcome{ExecutorID: input.Transaction.SignerID},
+		},
+	)
+
+	receipts, err := Receipts(block, executedIn, []near.TransactionDetails{*input})
+	assert.NoError(t, err)
+	assert.Len(t, receipts, 2)
+
+	assert.Equal(t, "receipt1", receipts[0].ReceiptID)
+	assert.Equal(t, "FujFFVfCor3X4h9XXyBNvjCZ8AbhNh64T8kXSUdzY8k3", receipts[0].TransactionHash)
+	assert.Equal(t, "24530697.betanet", receipts[0].Signer)
+	assert.Equal(t, "pool.betanet", receipts[0].Receiver)
+	assert.Equal(t, "100", receipts[0].GasBurnt)
+	assert.Equal(t, "10", receipts[0].TokensBurnt.String())
+	assert.Equal(t, "101", receipts[0].Height.String())
+	assert.Equal(t, int64(1596166783), receipts[0].Time.Unix())
+	assert.True(t, receipts[0].Success)
+
+	assert.Equal(t, "receipt2", receipts[1].ReceiptID)
+	assert.False(t, receipts[1].Success)
+	assert.Equal(t, "100", receipts[1].Height.String())
+}
@@ -16,14 +16,16 @@ func Transaction(block *near.Block, input *near.TransactionDetails) (*model.Tran
 	tx := input.Transaction
 
 	t := &model.Transaction{
-		Hash:      tx.Hash,
-		BlockHash: block.Header.Hash,
-		Height:    types.Height(block.Header.Height),
-		Time:      util.ParseTime(block.Header.Timestamp),
-		Sender:    tx.SignerID,
-		Receiver:  tx.ReceiverID,
-		Amount:    types.NewAmount("0"),
-		GasBurnt:  fmt.Sprintf("%v", input.TransactionOutcome.Outcome.GasBurnt),
+		Hash:        tx.Hash,
+		BlockHash:   block.Header.Hash,
+		Height:      types.Height(block.Header.Height),
+		Time:        util.ParseTime(block.Header.Timestamp),
+		Sender:      tx.SignerID,
+		Receiver:    tx.ReceiverID,
+		Amount:      types.NewAmount("0"),
+		GasBurnt:    fmt.Sprintf("%v", input.TransactionOutcome.Outcome.GasBurnt),
+		TokensBurnt: tokensBurnt(input),
+		Fee:         transactionFee(input),
 	}
 
 	// Status field may be represented by different types depending on the situation
@@ -43,6 +45,7 @@ func Transaction(block *near.Block, input *near.TransactionDetails) (*model.Tran
 		}
 		t.Actions = reencoded
 		t.ActionsCount = len(actions)
+		t.Amount = actionsDeposit(actions)
 	}
 
 	if err := t.Validate(); err != nil {
@@ -67,3 +70,35 @@ func Transactions(block *near.Block, details []near.TransactionDetails) ([]model
 
 	return result, nil
 }
+
+// tokensBurnt returns the total amount of tokens burnt by the transaction and its receipts
+func tokensBurnt(input *near.TransactionDetails) types.Amount {
+	total := types.NewAmount(input.TransactionOutcome.Outcome.TokensBurnt)
+	for _, receipt := range input.ReceiptsOutcome {
+		total = total.Add(types.NewAmount(receipt.Outcome.TokensBurnt))
+	}
+	return total
+}
+
+// transactionFee returns the fee paid for gas burnt by the transaction and its receipts.
+// Receipts may execute in later blocks with a different gas price, so the fee is
+// the amount of tokens burnt by all outcomes rather than gas times the block gas price.
+func transactionFee(input *near.TransactionDetails) types.Amount {
+	return tokensBurnt(input)
+}
+
+// actionsDeposit returns the total amount of tokens attached to transaction actions
+func actionsDeposit(actions []near.Action) types.Amount {
+	total := types.NewAmount("0")
+
+	for _, action := range actions {
+		switch data := action.Data.(type) {
+		case *near.TransferAction:
+			total = total.Add(types.NewAmount(data.Deposit))
+		case *near.FunctionCallAction:
+			total = total.Add(types.NewAmount(data.Deposit))
+		}
+	}
+
+	return total
+}
@@ -0,0 +1,49 @@
+package mapper
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/figment-networks/near-indexer/near"
+)
+
+func loadTransactionFixture(t *testing.T, name string) *near.TransactionDetails {
+	data, err := ioutil.ReadFile("../../test/fixtures/" + name)
+	assert.NoError(t, err)
+
+	tx := &near.TransactionDetails{}
+	assert.NoError(t, json.Unmarshal(data, tx))
+
+	return tx
+}
+
+func TestTransaction(t *testing.T) {
+	block := &near.Block{
+		Header: near.BlockHeader{
+			Hash:      "blockhash",
+			Height:    100,
+			Timestamp: 1596166782911378000,
+			GasPrice:  "100",
+		},
+	}
+
+	input := loadTransactionFixture(t, "transaction_deposit.json")
+	input.TransactionOutcome.Outcome.TokensBurnt = "100"
+	input.ReceiptsOutcome[0].Outcome.TokensBurnt = "50"
+
+	tx, err := Transaction(block, input)
+	assert.NoError(t, err)
+	assert.Equal(t, "FujFFVfCor3X4h9XXyBNvjCZ8AbhNh64T8kXSUdzY8k3", tx.Hash)
+	assert.Equal(t, "blockhash", tx.BlockHash)
+	assert.Equal(t, "24530697.betanet", tx.Sender)
+	assert.Equal(t, "skywalker.betanet", tx.Receiver)
+	assert.Equal(t, "937144500000", tx.GasBurnt)
+	assert.Equal(t, "499000000000000000000000000", tx.Amount.String())
+	assert.Equal(t, "150", tx.TokensBurnt.String())
+	assert.Equal(t, "150", tx.Fee.String())
+	assert.Equal(t, 1, tx.ActionsCount)
+	assert.True(t, tx.Success)
+}
@@ -16,8 +16,11 @@ func Validator(block *near.Block, v *near.Validator) (*model.Validator, error) {
 		Epoch:          block.Header.EpochID,
 		ExpectedBlocks: v.NumExpectedBlocks,
 		ProducedBlocks: v.NumProducedBlocks,
+		ExpectedChunks: v.NumExpectedChunks,
+		ProducedChunks: v.NumProducedChunks,
 		Stake:          types.NewAmount(v.Stake),
 		Efficiency:     util.Percentage(v.NumExpectedBlocks, v.NumProducedBlocks),
+		Uptime:         validatorUptime(v),
 	}
 
 	return result, result.Validate()
@@ -36,11 +39,111 @@ func ValidatorAgg(block *near.Block, v *near.Validator) (*model.ValidatorAgg, er
 		AccountID:      v.AccountID,
 		ExpectedBlocks: v.NumExpectedBlocks,
 		ProducedBlocks: v.NumProducedBlocks,
+		ExpectedChunks: v.NumExpectedChunks,
+		ProducedChunks: v.NumProducedChunks,
 		Stake:          types.NewAmount(v.Stake),
 		Efficiency:     util.Percentage(v.NumExpectedBlocks, v.NumProducedBlocks),
+		Uptime:         validatorUptime(v),
 		Active:         true,
 		Slashed:        v.IsSlashed,
 	}
 
 	return result, nil
 }
+
+// validatorUptime returns the combined block and chunk production score
+func validatorUptime(v *near.Validator) float64 {
+	return util.Uptime(v.NumExpectedBlocks, v.NumProducedBlocks, v.NumExpectedChunks, v.NumProducedChunks)
+}
+
+// ValidatorProposals constructs a set of stake proposals included in the block
+func ValidatorProposals(block *near.Block) ([]model.ValidatorProposal, error) {
+	height := types.Height(block.Header.Height)
+	time := util.ParseTime(block.Header.Timestamp)
+
+	proposals := block.Header.ValidatorProposals
+	for _, chunk := range block.Chunks {
+		proposals = append(proposals, chunk.ValidatorProposals...)
+	}
+
+	seen := map[string]bool{}
+	result := []model.ValidatorProposal{}
+
+	for _, p := range proposals {
+		if seen[p.AccountID] {
+			continue
+		}
+		seen[p.AccountID] = true
+
+		proposal := model.ValidatorProposal{
+			Height:    height,
+			Time:      time,
+			Epoch:     block.Header.EpochID,
+			AccountID: p.AccountID,
+			PublicKey: p.PublicKey,
+			Stake:     types.NewAmount(p.Stake),
+		}
+		if err := proposal.Validate(); err != nil {
+			return nil, err
+		}
+		result = append(result, proposal)
+	}
+
+	return result, nil
+}
+
+// NextValidatorSet constructs the projected validator set for the next epoch
+func NextValidatorSet(resp *near.ValidatorsResponse) *model.ValidatorSet {
+	current := map[string]bool{}
+	for _, v := range resp.CurrentValidators {
+		current[v.AccountID] = true
+	}
+
+	set := &model.ValidatorSet{
+		EpochStartHeight: resp.EpochStartHeight,
+		SeatPrice:        types.NewAmount("0"),
+		TotalStake:       types.NewAmount("0"),
+		ValidatorsCount:  len(resp.NextValidators),
+		Validators:       make([]model.ValidatorSetItem, len(resp.NextValidators)),
+		Leaving:          []string{},
+		Proposals:        make([]model.ValidatorSetItem, len(resp.CurrentProposales)),
+	}
+
+	next := map[string]bool{}
+	for i, v := range resp.NextValidators {
+		next[v.AccountID] = true
+		stake := types.NewAmount(v.Stake)
+
+		status := model.ValidatorSetStatusRetained
+		if !current[v.AccountID] {
+			status = model.ValidatorSetStatusNew
+		}
+
+		set.Validators[i] = model.ValidatorSetItem{
+			AccountID: v.AccountID,
+			Stake:     stake,
+			Shards:    v.Shards,
+			Status:    status,
+		}
+
+		set.TotalStake = set.TotalStake.Add(stake)
+		if i == 0 || stake.Compare(set.SeatPrice) < 0 {
+			set.SeatPrice = stake
+		}
+	}
+
+	for _, v := range resp.CurrentValidators {
+		if !next[v.AccountID] {
+			set.Leaving = append(set.Leaving, v.AccountID)
+		}
+	}
+
+	for i, p := range resp.CurrentProposales {
+		set.Proposals[i] = model.ValidatorSetItem{
+			AccountID: p.AccountID,
+			Stake:     types.NewAmount(p.Stake),
+		}
+	}
+
+	return set
+}
@@ -0,0 +1,63 @@
+package mapper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/figment-networks/near-indexer/model"
+	"github.com/figment-networks/near-indexer/model/types"
+	"github.com/figment-networks/near-indexer/near"
+)
+
+func TestValidatorProposals(t *testing.T) {
+	block := &near.Block{
+		Header: near.BlockHeader{
+			Height:    10885359,
+			Timestamp: 1596166782911378000,
+			EpochID:   "epoch",
+			ValidatorProposals: []near.ValidatorProposal{
+				{AccountID: "a", Stake: "100"},
+			},
+		},
+		Chunks: []near.BlockChunk{
+			{ValidatorProposals: []near.ValidatorProposal{{AccountID: "a", Stake: "100"}, {AccountID: "b", Stake: "200"}}},
+		},
+	}
+
+	proposals, err := ValidatorProposals(block)
+	assert.NoError(t, err)
+	assert.Len(t, proposals, 2)
+	assert.Equal(t, "a", proposals[0].AccountID)
+	assert.Equal(t, "b", proposals[1].AccountID)
+	assert.Equal(t, types.NewAmount("200"), proposals[1].Stake)
+	assert.Equal(t, types.Height(10885359), proposals[1].Height)
+	assert.Equal(t, "epoch", proposals[1].Epoch)
+}
+
+func TestNextValidatorSet(t *testing.T) {
+	resp := &near.ValidatorsResponse{
+		EpochStartHeight: 100,
+		CurrentValidators: []near.Validator{
+			{AccountID: "a", Stake: "300"},
+			{AccountID: "b", Stake: "200"},
+		},
+		NextValidators: []near.Validator{
+			{AccountID: "a", Stake: "300"},
+			{AccountID: "c", Stake: "150"},
+		},
+		CurrentProposales: []near.ValidatorProposal{
+			{AccountID: "d", Stake: "50"},
+		},
+	}
+
+	set := NextValidatorSet(resp)
+	assert.Equal(t, uint64(100), set.EpochStartHeight)
+	assert.Equal(t, 2, set.ValidatorsCount)
+	assert.Equal(t, "150", set.SeatPrice.String())
+	assert.Equal(t, "450", set.TotalStake.String())
+	assert.Equal(t, model.ValidatorSetStatusRetained, set.Validators[0].Status)
+	assert.Equal(t, model.ValidatorSetStatusNew, set.Validators[1].Status)
+	assert.Equal(t, []string{"b"}, set.Leaving)
+	assert.Equal(t, "d", set.Proposals[0].AccountID)
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/figment-networks/near-indexer/model/types"
+)
+
+var (
+	errReceiptIDInvalid       = errors.New("receipt id is invalid")
+	errReceiptTxHashInvalid   = errors.New("transaction hash is invalid")
+	errReceiptReceiverInvalid = errors.New("receiver is invalid")
+)
+
+// Receipt is a transaction receipt executed on an account other than the
+// transaction signer and receiver, e.g. a cross-contract call.
+// Receipts are recorded at the height of the block where they were executed.
+type Receipt struct {
+	Model
+
+	ReceiptID       string       `json:"receipt_id"`
+	TransactionHash string       `json:"transaction_hash"`
+	Height          types.Height `json:"height"`
+	Time            time.Time    `json:"time"`
+	Signer          string       `json:"signer"`
+	Receiver        string       `json:"receiver"`
+	GasBurnt        string       `json:"gas_burnt"`
+	TokensBurnt     types.Amount `json:"tokens_burnt"`
+	Success         bool         `json:"success"`
+}
+
+func (Receipt) TableName() string {
+	return "receipts"
+}
+
+// Validate returns an error if receipt is invalid
+func (r Receipt) Validate() error {
+	if r.ReceiptID == "" {
+		return errReceiptIDInvalid
+	}
+	if r.TransactionHash == "" {
+		return errReceiptTxHashInvalid
+	}
+	if r.Receiver == "" {
+		return errReceiptReceiverInvalid
+	}
+	if !r.Height.Valid() {
+		return errTxHeightInvalid
+	}
+	if r.Time.IsZero() {
+		return errTxTimeInvalid
+	}
+	return nil
+}
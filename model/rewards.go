@@ -16,11 +16,13 @@ const (
 	TimeIntervalDaily TimeInterval = iota
 	TimeIntervalMonthly
 	TimeIntervalYearly
+	TimeIntervalWeekly
 )
 
 var (
 	TimeIntervalTypes = map[string]TimeInterval{
 		"daily":   TimeIntervalDaily,
+		"weekly":  TimeIntervalWeekly,
 		"monthly": TimeIntervalMonthly,
 		"yearly":  TimeIntervalYearly,
 	}
@@ -35,6 +37,8 @@ func (k TimeInterval) String() string {
 	switch k {
 	case TimeIntervalDaily:
 		return "YYYY-MM-DD"
+	case TimeIntervalWeekly:
+		return `IYYY-"W"IW`
 	case TimeIntervalMonthly:
 		return "YYYY-MM"
 	case TimeIntervalYearly:
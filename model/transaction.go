@@ -25,8 +25,10 @@ type Transaction struct {
 	BlockHash    string          `json:"block_hash"`
 	Sender       string          `json:"sender"`
 	Receiver     string          `json:"receiver"`
-	Amount       types.Amount    `json:"-"`
+	Amount       types.Amount    `json:"amount"`
 	GasBurnt     string          `json:"gas_burnt"`
+	TokensBurnt  types.Amount    `json:"tokens_burnt"`
+	Fee          types.Amount    `json:"fee"`
 	Actions      json.RawMessage `json:"actions"`
 	ActionsCount int             `json:"actions_count"`
 	Success      bool            `json:"success"`
@@ -5,6 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	"math/big"
+	"strings"
+)
+
+const (
+	// nearDecimals is the number of yocto decimals in a single NEAR token
+	nearDecimals = 24
 )
 
 var (
@@ -57,11 +63,47 @@ func (a Amount) String() string {
 	return a.Int.String()
 }
 
+// NEAR returns the amount formatted in NEAR tokens
+func (a Amount) NEAR() string {
+	if a.Int == nil {
+		return ""
+	}
+
+	digits := new(big.Int).Abs(a.Int).String()
+	if len(digits) <= nearDecimals {
+		digits = strings.Repeat("0", nearDecimals-len(digits)+1) + digits
+	}
+
+	result := digits[:len(digits)-nearDecimals]
+	if frac := strings.TrimRight(digits[len(digits)-nearDecimals:], "0"); frac != "" {
+		result += "." + frac
+	}
+	if a.Sign() < 0 {
+		result = "-" + result
+	}
+
+	return result
+}
+
 // Compare compares two amounts
 func (a Amount) Compare(b Amount) int {
 	return a.Cmp(b.Int)
 }
 
+// Add adds a given amount to the current one
+func (a Amount) Add(b Amount) Amount {
+	n := new(big.Int)
+	n.Add(a.Int, b.Int)
+	return Amount{n}
+}
+
+// Mul multiplies the current amount by a given one
+func (a Amount) Mul(b Amount) Amount {
+	n := new(big.Int)
+	n.Mul(a.Int, b.Int)
+	return Amount{n}
+}
+
 // Sub substitutes a given amount from the current one
 func (a Amount) Sub(b Amount) Amount {
 	n := new(big.Int)
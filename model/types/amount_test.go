@@ -11,3 +11,28 @@ func TestNewAmount(t *testing.T) {
 	assert.Equal(t, "0", NewAmount("0").String())
 	assert.Equal(t, "10", NewAmount("10").String())
 }
+
+func TestAmountAdd(t *testing.T) {
+	a := NewAmount("100000000000000000000000000")
+	b := NewAmount("5")
+
+	assert.Equal(t, "100000000000000000000000005", a.Add(b).String())
+	assert.Equal(t, "100000000000000000000000000", a.String())
+}
+
+func TestAmountMul(t *testing.T) {
+	a := NewAmount("100000000")
+	b := NewAmount("2428023852467")
+
+	assert.Equal(t, "242802385246700000000", a.Mul(b).String())
+	assert.Equal(t, "100000000", a.String())
+}
+
+func TestAmountNEAR(t *testing.T) {
+	assert.Equal(t, "0", NewAmount("0").NEAR())
+	assert.Equal(t, "1", NewAmount("1000000000000000000000000").NEAR())
+	assert.Equal(t, "0.000000000000000000000001", NewAmount("1").NEAR())
+	assert.Equal(t, "12.5", NewAmount("12500000000000000000000000").NEAR())
+	assert.Equal(t, "-0.25", NewAmount("-250000000000000000000000").NEAR())
+	assert.Equal(t, "", Amount{}.NEAR())
+}
@@ -1,5 +1,10 @@
 package util
 
+import (
+	"math/big"
+	"sort"
+)
+
 // Percentage returns a percentage value for given inputs
 func Percentage(max int, cur int) float64 {
 	if max < 0 && cur < 0 {
@@ -11,3 +16,47 @@ func Percentage(max int, cur int) float64 {
 
 	return (float64(cur) * 100.0) / float64(max)
 }
+
+// Uptime returns a combined block and chunk production score
+func Uptime(expectedBlocks, producedBlocks, expectedChunks, producedChunks int) float64 {
+	if expectedChunks <= 0 {
+		return Percentage(expectedBlocks, producedBlocks)
+	}
+	if expectedBlocks <= 0 {
+		return Percentage(expectedChunks, producedChunks)
+	}
+
+	blocks := Percentage(expectedBlocks, producedBlocks)
+	chunks := Percentage(expectedChunks, producedChunks)
+
+	return (blocks + chunks) / 2
+}
+
+// NakamotoCoefficient returns the minimum number of stakes controlling over 1/3 of the total
+func NakamotoCoefficient(stakes []*big.Int) int {
+	sorted := make([]*big.Int, len(stakes))
+	copy(sorted, stakes)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Cmp(sorted[j]) > 0
+	})
+
+	total := new(big.Int)
+	for _, stake := range sorted {
+		total.Add(total, stake)
+	}
+	if total.Sign() == 0 {
+		return 0
+	}
+
+	sum := new(big.Int)
+	three := big.NewInt(3)
+
+	for idx, stake := range sorted {
+		sum.Add(sum, stake)
+		if new(big.Int).Mul(sum, three).Cmp(total) > 0 {
+			return idx + 1
+		}
+	}
+
+	return len(sorted)
+}
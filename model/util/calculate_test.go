@@ -0,0 +1,39 @@
+package util
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPercentage(t *testing.T) {
+	assert.Equal(t, 0.0, Percentage(0, 10))
+	assert.Equal(t, 50.0, Percentage(10, 5))
+	assert.Equal(t, 100.0, Percentage(10, 10))
+}
+
+func TestUptime(t *testing.T) {
+	assert.Equal(t, 0.0, Uptime(0, 0, 0, 0))
+	assert.Equal(t, 90.0, Uptime(10, 9, 0, 0))
+	assert.Equal(t, 80.0, Uptime(0, 0, 10, 8))
+	assert.Equal(t, 85.0, Uptime(10, 9, 10, 8))
+	assert.Equal(t, 50.0, Uptime(10, 10, 10, 0))
+}
+
+func TestNakamotoCoefficient(t *testing.T) {
+	stakes := func(vals ...int64) []*big.Int {
+		result := make([]*big.Int, len(vals))
+		for i, v := range vals {
+			result[i] = big.NewInt(v)
+		}
+		return result
+	}
+
+	assert.Equal(t, 0, NakamotoCoefficient(nil))
+	assert.Equal(t, 0, NakamotoCoefficient(stakes(0, 0)))
+	assert.Equal(t, 1, NakamotoCoefficient(stakes(10)))
+	assert.Equal(t, 1, NakamotoCoefficient(stakes(10, 40, 50)))
+	assert.Equal(t, 3, NakamotoCoefficient(stakes(10, 10, 10, 10, 10, 10)))
+	assert.Equal(t, 2, NakamotoCoefficient(stakes(5, 30, 20, 10, 25, 10)))
+}
@@ -9,6 +9,16 @@ func ParseTime(src int64) time.Time {
 	return time.Unix(0, src)
 }
 
+// MinuteInterval returns a time interval for a minute
+func MinuteInterval(t time.Time) (time.Time, time.Time) {
+	year, month, day := t.Date()
+
+	start := time.Date(year, month, day, t.Hour(), t.Minute(), 0, 0, t.Location())
+	end := time.Date(year, month, day, t.Hour(), t.Minute(), 59, 0, t.Location())
+
+	return start, end
+}
+
 // HourInterval returns a time interval for an hour
 func HourInterval(t time.Time) (time.Time, time.Time) {
 	year, month, day := t.Date()
@@ -28,3 +38,24 @@ func DayInterval(t time.Time) (time.Time, time.Time) {
 
 	return start, end
 }
+
+// WeekInterval returns a time interval for a week starting on Monday
+func WeekInterval(t time.Time) (time.Time, time.Time) {
+	year, month, day := t.Date()
+	offset := (int(t.Weekday()) + 6) % 7
+
+	start := time.Date(year, month, day-offset, 0, 0, 0, 0, t.Location())
+	end := time.Date(year, month, day-offset+6, 23, 59, 59, 0, t.Location())
+
+	return start, end
+}
+
+// MonthInterval returns a time interval for a calendar month
+func MonthInterval(t time.Time) (time.Time, time.Time) {
+	year, month, _ := t.Date()
+
+	start := time.Date(year, month, 1, 0, 0, 0, 0, t.Location())
+	end := time.Date(year, month+1, 0, 23, 59, 59, 0, t.Location())
+
+	return start, end
+}
@@ -15,6 +15,15 @@ func TestParseTime(t *testing.T) {
 	assert.Equal(t, "2020-07-31T03:39:42.911378Z", ParseTime(1596166782911378000).UTC().Format(time.RFC3339Nano))
 }
 
+func TestMinuteInterval(t *testing.T) {
+	now, err := time.Parse(time.RFC3339, "2020-07-30T22:39:42Z")
+	assert.NoError(t, err)
+
+	start, end := MinuteInterval(now)
+	assert.Equal(t, "2020-07-30T22:39:00Z", start.Format(time.RFC3339))
+	assert.Equal(t, "2020-07-30T22:39:59Z", end.Format(time.RFC3339))
+}
+
 func TestHourInterval(t *testing.T) {
 	now, err := time.Parse(time.RFC3339, "2020-07-30T22:39:42Z")
 	assert.NoError(t, err)
@@ -32,3 +41,32 @@ func TestDayInterval(t *testing.T) {
 	assert.Equal(t, "2020-07-30T00:00:00Z", start.Format(time.RFC3339))
 	assert.Equal(t, "2020-07-30T23:59:59Z", end.Format(time.RFC3339))
 }
+
+func TestWeekInterval(t *testing.T) {
+	now, err := time.Parse(time.RFC3339, "2020-07-30T22:39:42Z")
+	assert.NoError(t, err)
+
+	start, end := WeekInterval(now)
+	assert.Equal(t, "2020-07-27T00:00:00Z", start.Format(time.RFC3339))
+	assert.Equal(t, "2020-08-02T23:59:59Z", end.Format(time.RFC3339))
+
+	sunday, err := time.Parse(time.RFC3339, "2020-08-02T10:00:00Z")
+	assert.NoError(t, err)
+
+	start, end = WeekInterval(sunday)
+	assert.Equal(t, "2020-07-27T00:00:00Z", start.Format(time.RFC3339))
+	assert.Equal(t, "2020-08-02T23:59:59Z", end.Format(time.RFC3339))
+}
+
+func TestMonthInterval(t *testing.T) {
+	now, err := time.Parse(time.RFC3339, "2020-02-15T22:39:42Z")
+	assert.NoError(t, err)
+
+	start, end := MonthInterval(now)
+	assert.Equal(t, "2020-02-01T00:00:00Z", start.Format(time.RFC3339))
+	assert.Equal(t, "2020-02-29T23:59:59Z", end.Format(time.RFC3339))
+
+	start, end = MonthInterval(start.AddDate(0, 10, 0))
+	assert.Equal(t, "2020-12-01T00:00:00Z", start.Format(time.RFC3339))
+	assert.Equal(t, "2020-12-31T23:59:59Z", end.Format(time.RFC3339))
+}
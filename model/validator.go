@@ -15,9 +15,12 @@ type Validator struct {
 	Epoch          string       `json:"epoch"`
 	ExpectedBlocks int          `json:"expected_blocks"`
 	ProducedBlocks int          `json:"produced_blocks"`
+	ExpectedChunks int          `json:"expected_chunks"`
+	ProducedChunks int          `json:"produced_chunks"`
 	Slashed        bool         `json:"slashed"`
 	Stake          types.Amount `json:"stake"`
 	Efficiency     float64      `json:"efficiency"`
+	Uptime         float64      `json:"uptime"`
 	RewardFee      *int         `json:"reward_fee"`
 	CreatedAt      time.Time    `json:"-"`
 	UpdatedAt      time.Time    `json:"-"`
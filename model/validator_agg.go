@@ -16,10 +16,13 @@ type ValidatorAgg struct {
 	LastTime       time.Time    `json:"last_time"`
 	ExpectedBlocks int          `json:"expected_blocks"`
 	ProducedBlocks int          `json:"produced_blocks"`
+	ExpectedChunks int          `json:"expected_chunks"`
+	ProducedChunks int          `json:"produced_chunks"`
 	Active         bool         `json:"active"`
 	Slashed        bool         `json:"slashed"`
 	Stake          types.Amount `json:"stake"`
 	Efficiency     float64      `json:"efficiency"`
+	Uptime         float64      `json:"uptime"`
 	RewardFee      *int         `json:"reward_fee"`
 	CreatedAt      time.Time    `json:"-"`
 	UpdatedAt      time.Time    `json:"-"`
@@ -14,7 +14,10 @@ type ValidatorEpoch struct {
 	LastTime       time.Time    `json:"last_time"`
 	ExpectedBlocks int          `json:"expected_blocks"`
 	ProducedBlocks int          `json:"produced_blocks"`
+	ExpectedChunks int          `json:"expected_chunks"`
+	ProducedChunks int          `json:"produced_chunks"`
 	Efficiency     float64      `json:"efficiency"`
+	Uptime         float64      `json:"uptime"`
 	StakingBalance types.Amount `json:"staking_balance"`
 	RewardFee      *int         `json:"reward_fee"`
 }
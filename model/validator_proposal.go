@@ -0,0 +1,37 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/figment-networks/near-indexer/model/types"
+)
+
+// ValidatorProposal represents a stake proposal included in a block
+type ValidatorProposal struct {
+	ID        int64        `json:"-"`
+	Height    types.Height `json:"height"`
+	Time      time.Time    `json:"time"`
+	Epoch     string       `json:"epoch"`
+	AccountID string       `json:"account_id"`
+	PublicKey string       `json:"public_key"`
+	Stake     types.Amount `json:"stake"`
+	CreatedAt time.Time    `json:"-"`
+}
+
+func (ValidatorProposal) TableName() string {
+	return "validator_proposals"
+}
+
+func (p ValidatorProposal) Validate() error {
+	if !p.Height.Valid() {
+		return errors.New("height is invalid")
+	}
+	if p.Time.IsZero() {
+		return errors.New("time is invalid")
+	}
+	if p.AccountID == "" {
+		return errors.New("account id is required")
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"github.com/figment-networks/near-indexer/model/types"
+)
+
+const (
+	ValidatorSetStatusNew      = "new"
+	ValidatorSetStatusRetained = "retained"
+)
+
+// ValidatorSetItem represents a single validator in the projected set
+type ValidatorSetItem struct {
+	AccountID string       `json:"account_id"`
+	Stake     types.Amount `json:"stake"`
+	Shards    []int        `json:"shards"`
+	Status    string       `json:"status"`
+}
+
+// ValidatorSet represents the projected validator set for the next epoch
+type ValidatorSet struct {
+	EpochStartHeight uint64             `json:"epoch_start_height"`
+	SeatPrice        types.Amount       `json:"seat_price"`
+	TotalStake       types.Amount       `json:"total_stake"`
+	ValidatorsCount  int                `json:"validators_count"`
+	Validators       []ValidatorSetItem `json:"validators"`
+	Leaving          []string           `json:"leaving"`
+	Proposals        []ValidatorSetItem `json:"proposals"`
+}
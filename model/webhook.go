@@ -0,0 +1,190 @@
+package model
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	WebhookDeliveryPending = "pending"
+	WebhookDeliverySuccess = "success"
+	WebhookDeliveryDead    = "dead"
+
+	// WebhookMaxAttempts is the number of delivery attempts before giving up
+	WebhookMaxAttempts = 10
+
+	webhookRetryBase = 30 * time.Second
+	webhookRetryMax  = time.Hour
+)
+
+var (
+	errWebhookURLInvalid    = errors.New("url is invalid")
+	errWebhookHostInvalid   = errors.New("url host is not allowed")
+	errWebhookActionInvalid = errors.New("event action is invalid")
+
+	// privateNetworks contains the private and shared address ranges
+	privateNetworks = parseNetworks(
+		"10.0.0.0/8",
+		"172.16.0.0/12",
+		"192.168.0.0/16",
+		"100.64.0.0/10",
+		"fc00::/7",
+	)
+
+	eventActions = map[string]bool{
+		ActionValidatorAdded:    true,
+		ActionValidatorRemoved:  true,
+		ActionValidatorKicked:   true,
+		ActionBalanceChanged:    true,
+		ActionProposalSubmitted: true,
+		ActionWillJoinNextEpoch: true,
+	}
+)
+
+// Webhook delivers matching events or account transactions to an URL.
+// Webhooks with an account filter receive transactions involving the account,
+// all other webhooks receive events matching the action and validator filters.
+type Webhook struct {
+	Model
+
+	APIKeyID    *int64         `json:"-"`
+	URL         string         `json:"url"`
+	Secret      string         `json:"-"`
+	Actions     pq.StringArray `json:"actions"`
+	ValidatorID string         `json:"validator_id"`
+	Account     string         `json:"account"`
+	Active      bool           `json:"active"`
+}
+
+// WebhookDelivery is a single webhook payload delivery
+type WebhookDelivery struct {
+	Model
+
+	WebhookID     int64           `json:"webhook_id"`
+	EventType     string          `json:"event_type"`
+	SourceID      int64           `json:"source_id"`
+	Payload       json.RawMessage `json:"payload"`
+	Status        string          `json:"status"`
+	Attempts      int             `json:"attempts"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	ResponseCode  int             `json:"response_code"`
+	Error         string          `json:"error"`
+	DeliveredAt   *time.Time      `json:"delivered_at"`
+}
+
+func (Webhook) TableName() string {
+	return "webhooks"
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// Validate returns an error if webhook is invalid
+func (w Webhook) Validate() error {
+	u, err := url.Parse(w.URL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return errWebhookURLInvalid
+	}
+	if !isPublicHost(u.Hostname()) {
+		return errWebhookHostInvalid
+	}
+	for _, action := range w.Actions {
+		if !eventActions[action] {
+			return errWebhookActionInvalid
+		}
+	}
+	return nil
+}
+
+// IsPrivateIP returns true if the IP address is not reachable from the public
+// network, e.g. loopback, link-local or private network addresses
+func IsPrivateIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return true
+	}
+	for _, network := range privateNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPublicHost returns true if the host name or IP address could be public.
+// Names are resolved by the worker, which also refuses connecting to private IPs.
+func isPublicHost(host string) bool {
+	if ip := net.ParseIP(host); ip != nil {
+		return !IsPrivateIP(ip)
+	}
+
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if !strings.Contains(host, ".") {
+		return false
+	}
+	for _, suffix := range []string{".localhost", ".local", ".internal"} {
+		if strings.HasSuffix(host, suffix) {
+			return false
+		}
+	}
+	return true
+}
+
+func parseNetworks(cidrs ...string) []*net.IPNet {
+	result := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		result[i] = network
+	}
+	return result
+}
+
+// Sign returns the HMAC-SHA256 signature of the payload
+func (w Webhook) Sign(payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(w.Secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Succeed marks the delivery as successful
+func (d *WebhookDelivery) Succeed(code int, ts time.Time) {
+	d.Attempts++
+	d.Status = WebhookDeliverySuccess
+	d.ResponseCode = code
+	d.Error = ""
+	d.DeliveredAt = &ts
+}
+
+// Fail records a failed attempt and schedules the next one with an exponential
+// backoff. Delivery is moved to the dead state after the max number of attempts.
+func (d *WebhookDelivery) Fail(code int, err error, ts time.Time) {
+	d.Attempts++
+	d.ResponseCode = code
+	if err != nil {
+		d.Error = err.Error()
+	}
+
+	if d.Attempts >= WebhookMaxAttempts {
+		d.Status = WebhookDeliveryDead
+		return
+	}
+
+	delay := webhookRetryBase << uint(d.Attempts-1)
+	if delay > webhookRetryMax || delay <= 0 {
+		delay = webhookRetryMax
+	}
+	d.NextAttemptAt = ts.Add(delay)
+}
@@ -0,0 +1,88 @@
+package model
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookValidate(t *testing.T) {
+	assert.NoError(t, Webhook{URL: "https://example.com/hook"}.Validate())
+	assert.NoError(t, Webhook{URL: "http://example.com", Actions: []string{ActionValidatorKicked}}.Validate())
+	assert.Equal(t, errWebhookURLInvalid, Webhook{URL: ""}.Validate())
+	assert.Equal(t, errWebhookURLInvalid, Webhook{URL: "ftp://example.com"}.Validate())
+	assert.Equal(t, errWebhookURLInvalid, Webhook{URL: "/hook"}.Validate())
+	assert.Equal(t, errWebhookActionInvalid, Webhook{URL: "https://example.com", Actions: []string{"foo"}}.Validate())
+
+	assert.NoError(t, Webhook{URL: "https://8.8.8.8/hook"}.Validate())
+	assert.NoError(t, Webhook{URL: "https://[2001:4860:4860::8888]/hook"}.Validate())
+	for _, u := range []string{
+		"http://localhost:8080/hook",
+		"http://127.0.0.1/hook",
+		"http://0.0.0.0/hook",
+		"http://10.1.2.3/hook",
+		"http://172.16.0.1/hook",
+		"http://192.168.1.1/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://[::1]/hook",
+		"http://[fe80::1]/hook",
+		"http://[fd00::1]/hook",
+		"http://metadata/hook",
+		"http://db.internal/hook",
+		"http://printer.local/hook",
+	} {
+		assert.Equal(t, errWebhookHostInvalid, Webhook{URL: u}.Validate(), u)
+	}
+}
+
+func TestIsPrivateIP(t *testing.T) {
+	assert.True(t, IsPrivateIP(net.ParseIP("127.0.0.2")))
+	assert.True(t, IsPrivateIP(net.ParseIP("100.64.0.1")))
+	assert.True(t, IsPrivateIP(net.ParseIP("::ffff:10.0.0.1")))
+	assert.False(t, IsPrivateIP(net.ParseIP("1.1.1.1")))
+	assert.False(t, IsPrivateIP(net.ParseIP("172.32.0.1")))
+}
+
+func TestWebhookSign(t *testing.T) {
+	w := Webhook{Secret: "secret"}
+	assert.Equal(t,
+		"sha256=0329a06b62cd16b33eb6792be8c60b158d89a2ee3a876fce9a881ebb488c0914",
+		w.Sign([]byte("test")),
+	)
+}
+
+func TestWebhookDeliveryFail(t *testing.T) {
+	ts := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	d := &WebhookDelivery{Status: WebhookDeliveryPending}
+
+	d.Fail(500, nil, ts)
+	assert.Equal(t, 1, d.Attempts)
+	assert.Equal(t, WebhookDeliveryPending, d.Status)
+	assert.Equal(t, ts.Add(30*time.Second), d.NextAttemptAt)
+
+	d.Fail(0, errors.New("timeout"), ts)
+	assert.Equal(t, ts.Add(time.Minute), d.NextAttemptAt)
+	assert.Equal(t, "timeout", d.Error)
+
+	for d.Attempts < WebhookMaxAttempts-1 {
+		d.Fail(500, nil, ts)
+	}
+	assert.Equal(t, ts.Add(webhookRetryMax), d.NextAttemptAt)
+
+	d.Fail(500, nil, ts)
+	assert.Equal(t, WebhookDeliveryDead, d.Status)
+}
+
+func TestWebhookDeliverySucceed(t *testing.T) {
+	ts := time.Now()
+	d := &WebhookDelivery{Status: WebhookDeliveryPending, Error: "timeout"}
+
+	d.Succeed(200, ts)
+	assert.Equal(t, WebhookDeliverySuccess, d.Status)
+	assert.Equal(t, 1, d.Attempts)
+	assert.Equal(t, "", d.Error)
+	assert.Equal(t, &ts, d.DeliveredAt)
+}
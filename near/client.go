@@ -71,6 +71,7 @@ type Client interface {
 	BlockByHash(string) (Block, error)
 	Chunk(string) (ChunkDetails, error)
 	Account(id string) (Account, error)
+	AccountAtBlock(string, interface{}) (Account, error)
 	AccountInfo(string, string, uint64) (*AccountInfo, error)
 	Transaction(string) (TransactionDetails, error)
 	GasPrice(string) (string, error)
@@ -211,6 +212,17 @@ func (c client) Account(id string) (acc Account, err error) {
 	return
 }
 
+// AccountAtBlock returns an account by id at the given block height or hash
+func (c client) AccountAtBlock(id string, block interface{}) (acc Account, err error) {
+	params := map[string]interface{}{
+		"request_type": "view_account",
+		"block_id":     block,
+		"account_id":   id,
+	}
+	err = c.Call(methodQuery, params, &acc)
+	return
+}
+
 // AccountInfo returns account delegation balance for a given pool address
 func (c client) AccountInfo(poolID string, lookupID string, blockID uint64) (*AccountInfo, error) {
 	callArgs, err := argsToBase64(map[string]interface{}{
@@ -254,6 +266,9 @@ func (c client) Transaction(id string) (tran TransactionDetails, err error) {
 func (c client) GasPrice(block string) (string, error) {
 	result := GasPriceDetails{}
 	args := []interface{}{nil}
+	if block != "" {
+		args = []interface{}{block}
+	}
 
 	err := c.Call(methodGasPrice, args, &result)
 	return result.GasPrice, err
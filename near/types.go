@@ -28,6 +28,7 @@ type GenesisConfig struct {
 	NumBlockProducerSeats int       `json:"num_block_producer_seats"`
 	EpochLength           int       `json:"epoch_length"`
 	TotalSupply           string    `json:"total_supply"`
+	ProtocolRewardRate    []int64   `json:"protocol_reward_rate"`
 	Validators            []struct {
 		AccountID string `json:"account_id"`
 		PublicKey string `json:"public_key"`
@@ -83,8 +84,6 @@ type BlockHeader struct {
 	ValidatorProposals    []ValidatorProposal `json:"validator_proposals"`
 	ChunkMask             []bool              `json:"chunk_mask"`
 	GasPrice              string              `json:"gas_price"`
-	RentPaid              string              `json:"rent_paid"`
-	ValidatorReward       string              `json:"validator_reward"`
 	TotalSupply           string              `json:"total_supply"`
 	ChallengesResult      []interface{}       `json:"challenges_result"`
 	LastFinalBlock        string              `json:"last_final_block"`
@@ -108,8 +107,6 @@ type BlockChunk struct {
 	ShardID              int                 `json:"shard_id"`
 	GasUsed              int                 `json:"gas_used"`
 	GasLimit             int64               `json:"gas_limit"`
-	RentPaid             string              `json:"rent_paid"`
-	ValidatorReward      string              `json:"validator_reward"`
 	BalanceBurnt         string              `json:"balance_burnt"`
 	OutgoingReceiptsRoot string              `json:"outgoing_receipts_root"`
 	TxRoot               string              `json:"tx_root"`
@@ -142,6 +139,8 @@ type Validator struct {
 	IsSlashed         bool   `json:"is_slashed"`
 	NumExpectedBlocks int    `json:"num_expected_blocks"`
 	NumProducedBlocks int    `json:"num_produced_blocks"`
+	NumExpectedChunks int    `json:"num_expected_chunks"`
+	NumProducedChunks int    `json:"num_produced_chunks"`
 	PublicKey         string `json:"public_key"`
 	Shards            []int  `json:"shards"`
 	Stake             string `json:"stake"`
@@ -165,10 +164,12 @@ type ActionError struct {
 }
 
 type Outcome struct {
-	GasBurnt   int64         `json:"gas_burnt"`
-	Logs       []interface{} `json:"logs"`
-	ReceiptIds []string      `json:"receipt_ids"`
-	Status     Status        `json:"status"`
+	ExecutorID  string        `json:"executor_id"`
+	GasBurnt    int64         `json:"gas_burnt"`
+	TokensBurnt string        `json:"tokens_burnt"`
+	Logs        []interface{} `json:"logs"`
+	ReceiptIds  []string      `json:"receipt_ids"`
+	Status      Status        `json:"status"`
 }
 
 type TransactionOutcome struct {
@@ -15,6 +15,7 @@ type Payload struct {
 	EndHeight   uint64
 	EndTime     time.Time
 	Tip         *near.Block
+	Genesis     *near.GenesisConfig
 	Heights     []*HeightPayload
 }
 
@@ -28,6 +29,7 @@ type HeightPayload struct {
 	Validators             []near.Validator
 	Chunks                 []near.ChunkDetails
 	Transactions           []near.TransactionDetails
+	ReceiptBlocks          map[string]near.BlockHeader
 	Delegations            []near.AccountInfo
 	Accounts               []near.Account
 	RewardFees             map[string]near.RewardFee
@@ -37,6 +39,7 @@ type HeightPayload struct {
 	PreviousValidators     []near.Validator
 	PreviousEpochKickOut   []near.ValidatorKickout
 	PreviousBlock          *near.Block
+	NextValidators         []near.Validator
 
 	Parsed *ParsedPayload
 }
@@ -52,10 +55,12 @@ type ParsedPayload struct {
 	Block           *model.Block
 	Epoch           *model.Epoch
 	Transactions    []model.Transaction
+	Receipts        []model.Receipt
 	Validators      []model.Validator
 	ValidatorAggs   []model.ValidatorAgg
 	ValidatorEpochs []model.ValidatorEpoch
 	DelegatorEpochs []model.DelegatorEpoch
+	Proposals       []model.ValidatorProposal
 	Accounts        []model.Account
 	Events          []model.Event
 }
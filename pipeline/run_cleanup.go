@@ -1,6 +1,8 @@
 package pipeline
 
 import (
+	"time"
+
 	"github.com/sirupsen/logrus"
 
 	"github.com/figment-networks/near-indexer/config"
@@ -23,5 +25,38 @@ func RunCleanup(cfg *config.Config, db *store.Store, logger *logrus.Logger) erro
 		logrus.WithError(err).Error("validators cleanup failed")
 	}
 
+	if numRows, err := db.Stats.PurgeValidatorCounts(); err == nil {
+		logrus.WithField("count", numRows).Info("validator counts removed")
+	} else {
+		logrus.WithError(err).Error("validator counts cleanup failed")
+	}
+
+	minuteStatsCutoff := lastBlock.Time.Add(-time.Hour * 24 * 7)
+	if numRows, err := db.Stats.PurgeBlockStats(store.BucketMinute, minuteStatsCutoff); err == nil {
+		logrus.WithField("count", numRows).Info("minute block stats removed")
+	} else {
+		logrus.WithError(err).Error("minute block stats cleanup failed")
+	}
+
+	timeRange := store.TimeRange{
+		Start: lastBlock.Time.Add(-cfg.CleanupDuration()),
+		End:   lastBlock.Time,
+	}
+	for _, bucket := range []string{store.BucketDay, store.BucketWeek, store.BucketMonth} {
+		if err := db.Stats.CreateActiveAccounts(bucket, timeRange); err != nil {
+			logrus.WithError(err).WithField("bucket", bucket).Error("active accounts stats failed")
+		}
+	}
+
+	// Long buckets scan a lot of data, so they're not rebuilt on every sync
+	for _, bucket := range []string{store.BucketWeek, store.BucketMonth} {
+		if err := db.Stats.CreateTransactionStats(bucket, timeRange); err != nil {
+			logrus.WithError(err).WithField("bucket", bucket).Error("transaction stats failed")
+		}
+		if err := db.Stats.CreateBlockStats(bucket, timeRange); err != nil {
+			logrus.WithError(err).WithField("bucket", bucket).Error("block stats failed")
+		}
+	}
+
 	return nil
 }
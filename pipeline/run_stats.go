@@ -0,0 +1,110 @@
+package pipeline
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/figment-networks/near-indexer/config"
+	"github.com/figment-networks/near-indexer/model/util"
+	"github.com/figment-networks/near-indexer/store"
+)
+
+// RunStatsBackfill rebuilds all time bucket stats for the indexed data
+func RunStatsBackfill(cfg *config.Config, db *store.Store, logger *logrus.Logger) error {
+	firstBlock, err := db.Blocks.First()
+	if err != nil {
+		return err
+	}
+
+	lastBlock, err := db.Blocks.Last()
+	if err != nil {
+		return err
+	}
+
+	logger.
+		WithField("from", firstBlock.ID).
+		WithField("to", lastBlock.ID).
+		Info("creating validator counts")
+
+	heightRange := store.HeightRange{
+		Start: uint64(firstBlock.ID),
+		End:   uint64(lastBlock.ID),
+	}
+	if err := db.Stats.CreateValidatorCounts(heightRange); err != nil {
+		return err
+	}
+
+	for _, bucket := range []string{store.BucketDay, store.BucketWeek, store.BucketMonth} {
+		logger.WithField("bucket", bucket).Info("creating active accounts stats")
+
+		timeRange := store.TimeRange{
+			Start: firstBlock.Time,
+			End:   lastBlock.Time,
+		}
+		if err := db.Stats.CreateActiveAccounts(bucket, timeRange); err != nil {
+			return err
+		}
+	}
+
+	// Process one calendar day at a time to keep the queries reasonably small
+	firstDay, _ := util.DayInterval(firstBlock.Time)
+	for day := firstDay; !day.After(lastBlock.Time); day = day.AddDate(0, 0, 1) {
+		logger.WithField("day", day.Format("2006-01-02")).Info("creating stats")
+
+		dayStart, dayEnd := util.DayInterval(day)
+		timeRange := store.TimeRange{
+			Start: dayStart,
+			End:   dayEnd,
+		}
+
+		for _, bucket := range []string{store.BucketHour, store.BucketDay} {
+			if err := db.Stats.CreateTransactionStats(bucket, timeRange); err != nil {
+				return err
+			}
+		}
+
+		for _, bucket := range []string{store.BucketMinute, store.BucketHour, store.BucketDay} {
+			if err := db.Stats.CreateBlockStats(bucket, timeRange); err != nil {
+				return err
+			}
+		}
+
+		for _, bucket := range []string{store.BucketHour, store.BucketDay} {
+			if err := db.Stats.CreateValidatorsStats(bucket, timeRange); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Week and month buckets are rebuilt once per bucket instead of every day
+	for _, bucket := range []string{store.BucketWeek, store.BucketMonth} {
+		for ts := firstBlock.Time; !ts.After(lastBlock.Time); {
+			timeRange := store.TimeRange{
+				Start: ts,
+				End:   ts,
+			}
+
+			bucketStart, bucketEnd, err := timeRange.FullRange(bucket)
+			if err != nil {
+				return err
+			}
+
+			logger.
+				WithField("bucket", bucket).
+				WithField("start", bucketStart.Format("2006-01-02")).
+				Info("creating stats")
+
+			if err := db.Stats.CreateTransactionStats(bucket, timeRange); err != nil {
+				return err
+			}
+			if err := db.Stats.CreateBlockStats(bucket, timeRange); err != nil {
+				return err
+			}
+
+			ts = bucketEnd.Add(time.Second)
+		}
+	}
+
+	return nil
+}
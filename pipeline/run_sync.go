@@ -44,12 +44,16 @@ func RunSync(cfg *config.Config, db *store.Store, clients []near.Client) (int, e
 	parserTask := NewParserTask(db, logger)
 	persistorTask := NewPersistorTask(db, logger)
 	analyzerTask := NewAnalyzerTask(db, logger)
+	publisherTask := NewPublisherTask(db, logger)
+	webhooksTask := NewWebhooksTask(db, logger)
 
 	tasks := []Task{
 		fetcherTask,
 		parserTask,
 		persistorTask,
 		analyzerTask,
+		publisherTask,
+		webhooksTask,
 	}
 
 	for _, t := range tasks {
@@ -0,0 +1,163 @@
+package pipeline
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/figment-networks/near-indexer/config"
+	"github.com/figment-networks/near-indexer/model"
+	"github.com/figment-networks/near-indexer/store"
+)
+
+const (
+	webhooksBatchSize   = 100
+	webhooksPerWebhook  = 10
+	webhooksConcurrency = 10
+	webhooksTimeout     = 10 * time.Second
+
+	// webhooksLease is how long claimed deliveries are hidden from other workers,
+	// it must be longer than sending all deliveries of a single webhook
+	webhooksLease = 5 * time.Minute
+)
+
+var (
+	errWebhookAddressInvalid = errors.New("webhook address is not allowed")
+)
+
+// RunWebhookDeliveries sends all pending webhook deliveries that are due.
+// Webhooks are processed concurrently, deliveries of each webhook are sent in order.
+func RunWebhookDeliveries(cfg *config.Config, db *store.Store, logger *logrus.Logger) error {
+	deliveries, err := db.Webhooks.ClaimDeliveries(webhooksBatchSize, webhooksPerWebhook, webhooksLease)
+	if err != nil {
+		return err
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	logger.WithField("count", len(deliveries)).Debug("sending webhook deliveries")
+
+	webhookIDs := []string{}
+	deliveriesByWebhook := map[string][]model.WebhookDelivery{}
+
+	for _, delivery := range deliveries {
+		id := strconv.FormatInt(delivery.WebhookID, 10)
+		if _, ok := deliveriesByWebhook[id]; !ok {
+			webhookIDs = append(webhookIDs, id)
+		}
+		deliveriesByWebhook[id] = append(deliveriesByWebhook[id], delivery)
+	}
+
+	client := newWebhooksClient()
+
+	var runErr error
+	errLock := &sync.Mutex{}
+
+	doConcurrently(webhookIDs, webhooksConcurrency, func(id string) {
+		if err := sendWebhookDeliveries(client, db, logger, deliveriesByWebhook[id]); err != nil {
+			errLock.Lock()
+			defer errLock.Unlock()
+			runErr = err
+		}
+	})
+
+	return runErr
+}
+
+// sendWebhookDeliveries sends the deliveries of a single webhook
+func sendWebhookDeliveries(client *http.Client, db *store.Store, logger *logrus.Logger, deliveries []model.WebhookDelivery) error {
+	webhook, err := db.Webhooks.FindByID(deliveries[0].WebhookID)
+	if err != nil {
+		return err
+	}
+
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		now := time.Now()
+
+		if !webhook.Active {
+			delivery.Status = model.WebhookDeliveryDead
+			delivery.Error = "webhook is inactive"
+		} else {
+			code, err := sendWebhook(client, webhook, delivery)
+			if err == nil && code >= 200 && code < 300 {
+				delivery.Succeed(code, now)
+			} else {
+				if err == nil {
+					err = fmt.Errorf("unexpected response status: %d", code)
+				}
+				delivery.Fail(code, err, now)
+			}
+		}
+
+		if err := db.Webhooks.Update(delivery); err != nil {
+			return err
+		}
+
+		logger.
+			WithField("webhook", webhook.ID).
+			WithField("delivery", delivery.ID).
+			WithField("status", delivery.Status).
+			WithField("attempts", delivery.Attempts).
+			Debug("webhook delivery processed")
+	}
+
+	return nil
+}
+
+// newWebhooksClient returns a HTTP client that refuses connecting to private
+// network addresses, so webhooks could not be used to reach internal services
+func newWebhooksClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: webhooksTimeout,
+		Control: func(network, address string, conn syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || model.IsPrivateIP(ip) {
+				return errWebhookAddressInvalid
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: webhooksTimeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: webhooksTimeout,
+		},
+	}
+}
+
+// sendWebhook posts the signed delivery payload to the webhook URL
+func sendWebhook(client *http.Client, webhook *model.Webhook, delivery *model.WebhookDelivery) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "near-indexer")
+	req.Header.Set("X-Webhook-Signature", webhook.Sign(delivery.Payload))
+	req.Header.Set("X-Webhook-Delivery", strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set("X-Webhook-Attempt", strconv.Itoa(delivery.Attempts+1))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
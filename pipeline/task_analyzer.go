@@ -51,6 +51,21 @@ func (t AnalyzerTask) Run(ctx context.Context, payload *Payload) error {
 		numPrev := len(h.PreviousValidators)
 		numCurrent := len(h.Validators)
 
+		if h.Parsed != nil {
+			for _, p := range h.Parsed.Proposals {
+				t.logger.
+					WithField("account", p.AccountID).
+					WithField("height", h.Height).
+					Debug("validator proposal submitted")
+
+				event, err := mapper.ProposalSubmittedEvent(h.Block, &p)
+				if err != nil {
+					return err
+				}
+				events = append(events, *event)
+			}
+		}
+
 		// Do not process any of events until epoch is complete
 		if h.CurrentEpoch {
 			continue
@@ -111,6 +126,19 @@ func (t AnalyzerTask) Run(ctx context.Context, payload *Payload) error {
 			// }
 		}
 
+		for _, v := range h.NextValidators {
+			if currentIds[v.AccountID] != nil {
+				continue
+			}
+			t.logger.WithField("account", v.AccountID).Info("validator will join next epoch")
+
+			event, err := mapper.ValidatorJoinNextEpochEvent(h.Block, &v)
+			if err != nil {
+				return err
+			}
+			events = append(events, *event)
+		}
+
 		for _, v := range h.PreviousValidators {
 			if currentIds[v.AccountID] != nil {
 				continue
@@ -18,6 +18,12 @@ const (
 	feeFetchConcurrency = 10
 )
 
+// genesisCache holds the genesis config, it never changes for a running network
+var genesisCache struct {
+	sync.Mutex
+	config *near.GenesisConfig
+}
+
 // FetcherTask performs fetching data from the network node
 type FetcherTask struct {
 	rpc      []near.Client
@@ -79,6 +85,22 @@ func (t *FetcherTask) RPC() near.Client {
 	return t.rpc[t.rpcIndex%len(t.rpc)]
 }
 
+// genesisConfig returns the network genesis config, fetched once per process
+func (t *FetcherTask) genesisConfig() (*near.GenesisConfig, error) {
+	genesisCache.Lock()
+	defer genesisCache.Unlock()
+
+	if genesisCache.config == nil {
+		genesis, err := t.RPC().GenesisConfig()
+		if err != nil {
+			return nil, err
+		}
+		genesisCache.config = &genesis
+	}
+
+	return genesisCache.config, nil
+}
+
 // Run executes the data fetching
 func (t FetcherTask) Run(ctx context.Context, payload *Payload) error {
 	defer logTaskDuration(t, time.Now())
@@ -98,6 +120,13 @@ func (t FetcherTask) Run(ctx context.Context, payload *Payload) error {
 	}
 	payload.Tip = &currentBlock
 
+	// Fetch the genesis config, used for the sync start and protocol parameters
+	genesis, err := t.genesisConfig()
+	if err != nil {
+		return err
+	}
+	payload.Genesis = genesis
+
 	// Fetch last indexed block
 	lastBlock, err := t.db.Blocks.Last()
 	if err != nil && err != store.ErrNotFound {
@@ -117,10 +146,6 @@ func (t FetcherTask) Run(ctx context.Context, payload *Payload) error {
 
 	// Get the genesis block height if no start height was provided
 	if startHeight == 0 {
-		genesis, err := t.RPC().GenesisConfig()
-		if err != nil {
-			return err
-		}
 		startHeight = genesis.GenesisHeight
 	}
 
@@ -214,6 +239,7 @@ func (t FetcherTask) Run(ctx context.Context, payload *Payload) error {
 
 			if validators != nil {
 				data.Validators = validators.CurrentValidators
+				data.NextValidators = validators.NextValidators
 				data.PreviousEpochKickOut = validators.PreviousEpochKickout
 				if previousValidators != nil {
 					data.PreviousValidators = previousValidators.CurrentValidators
@@ -362,6 +388,14 @@ func (t FetcherTask) fetchHeightData(height uint64) (payload *HeightPayload) {
 			}
 			payload.Transactions = transactions
 		}
+
+		// Receipts usually execute in the following blocks
+		receiptBlocks, err := t.fetchReceiptBlocks(&block, payload.Transactions)
+		if err != nil {
+			payload.Error = err
+			return
+		}
+		payload.ReceiptBlocks = receiptBlocks
 	}
 
 	return payload
@@ -404,6 +438,46 @@ func (t FetcherTask) fetchBlockTransactions(block *near.Block, hashes []string)
 	return txlist, nil
 }
 
+// fetchReceiptBlocks retrieves headers of the blocks where transaction receipts were executed
+func (t FetcherTask) fetchReceiptBlocks(block *near.Block, transactions []near.TransactionDetails) (map[string]near.BlockHeader, error) {
+	headers := map[string]near.BlockHeader{
+		block.Header.Hash: block.Header,
+	}
+
+	hashes := []string{}
+	for _, tx := range transactions {
+		for _, outcome := range tx.ReceiptsOutcome {
+			if _, ok := headers[outcome.BlockHash]; ok || outcome.BlockHash == "" {
+				continue
+			}
+			headers[outcome.BlockHash] = near.BlockHeader{}
+			hashes = append(hashes, outcome.BlockHash)
+		}
+	}
+
+	var fetchErr error
+	resultsLock := &sync.Mutex{}
+
+	doConcurrently(hashes, feeFetchConcurrency, func(hash string) {
+		receiptBlock, err := t.RPC().BlockByHash(hash)
+
+		resultsLock.Lock()
+		defer resultsLock.Unlock()
+
+		if err != nil {
+			fetchErr = err
+			return
+		}
+		headers[hash] = receiptBlock.Header
+	})
+
+	if fetchErr != nil {
+		return nil, fetchErr
+	}
+
+	return headers, nil
+}
+
 type feeFetchResult struct {
 	account string
 	fee     *near.RewardFee
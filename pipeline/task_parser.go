@@ -53,13 +53,9 @@ func (t ParserTask) Run(ctx context.Context, payload *Payload) error {
 		}
 		parsed.Block = block
 
-		epoch := &model.Epoch{
-			ID:              h.Block.Header.EpochID,
-			StartTime:       block.Time,
-			StartHeight:     uint64(block.ID),
-			EndTime:         block.Time,
-			EndHeight:       uint64(block.ID),
-			ValidatorsCount: 0,
+		epoch, err := mapper.Epoch(h.Block, h.Validators)
+		if err != nil {
+			return err
 		}
 		parsed.Epoch = epoch
 
@@ -130,7 +126,10 @@ func (t ParserTask) Run(ctx context.Context, payload *Payload) error {
 				LastTime:       validator.Time,
 				ExpectedBlocks: validator.ExpectedBlocks,
 				ProducedBlocks: validator.ProducedBlocks,
+				ExpectedChunks: validator.ExpectedChunks,
+				ProducedChunks: validator.ProducedChunks,
 				Efficiency:     validator.Efficiency,
+				Uptime:         validator.Uptime,
 				StakingBalance: validator.Stake,
 				RewardFee:      validator.RewardFee,
 			})
@@ -149,7 +148,10 @@ func (t ParserTask) Run(ctx context.Context, payload *Payload) error {
 				LastTime:       validator.Time,
 				ExpectedBlocks: validator.ExpectedBlocks,
 				ProducedBlocks: validator.ProducedBlocks,
+				ExpectedChunks: validator.ExpectedChunks,
+				ProducedChunks: validator.ProducedChunks,
 				Efficiency:     validator.Efficiency,
+				Uptime:         validator.Uptime,
 				StakingBalance: validator.Stake,
 				RewardFee:      validator.RewardFee,
 			})
@@ -165,6 +167,12 @@ func (t ParserTask) Run(ctx context.Context, payload *Payload) error {
 			}
 		}
 
+		proposals, err := mapper.ValidatorProposals(h.Block)
+		if err != nil {
+			return err
+		}
+		parsed.Proposals = proposals
+
 		transactions, err := mapper.Transactions(h.Block, h.Transactions)
 		if err != nil {
 			t.logger.
@@ -177,6 +185,17 @@ func (t ParserTask) Run(ctx context.Context, payload *Payload) error {
 		parsed.Transactions = append(parsed.Transactions, transactions...)
 		block.TransactionsCount = len(transactions)
 		parsed.Block.TransactionsCount = len(transactions)
+
+		receipts, err := mapper.Receipts(h.Block, h.ReceiptBlocks, h.Transactions)
+		if err != nil {
+			t.logger.
+				WithError(err).
+				WithField("block", h.Block.Header.Height).
+				Error(err)
+
+			return err
+		}
+		parsed.Receipts = append(parsed.Receipts, receipts...)
 	}
 
 	return nil
@@ -40,8 +40,10 @@ func (t PersistorTask) Run(ctx context.Context, payload *Payload) error {
 
 	blocks := []model.Block{}
 	transactions := []model.Transaction{}
+	receipts := []model.Receipt{}
+	proposals := []model.ValidatorProposal{}
 	epochs := []model.Epoch{}
-	epochIds := map[string]bool{}
+	epochIds := map[string]int{}
 
 	for _, h := range payload.Heights {
 		if h.Skip || h.Block == nil {
@@ -50,10 +52,21 @@ func (t PersistorTask) Run(ctx context.Context, payload *Payload) error {
 
 		blocks = append(blocks, *h.Parsed.Block)
 		transactions = append(transactions, h.Parsed.Transactions...)
+		receipts = append(receipts, h.Parsed.Receipts...)
+		proposals = append(proposals, h.Parsed.Proposals...)
 
-		if !epochIds[h.Parsed.Epoch.ID] {
-			epochIds[h.Parsed.Epoch.ID] = true
+		idx, ok := epochIds[h.Parsed.Epoch.ID]
+		if !ok {
+			epochIds[h.Parsed.Epoch.ID] = len(epochs)
 			epochs = append(epochs, *h.Parsed.Epoch)
+			continue
+		}
+
+		// Validators are only fetched on some heights, keep the most recent stake data
+		if h.Parsed.Epoch.HasStakeStats() {
+			epochs[idx].SeatPrice = h.Parsed.Epoch.SeatPrice
+			epochs[idx].TotalStake = h.Parsed.Epoch.TotalStake
+			epochs[idx].NakamotoCoefficient = h.Parsed.Epoch.NakamotoCoefficient
 		}
 	}
 
@@ -69,6 +82,14 @@ func (t PersistorTask) Run(ctx context.Context, payload *Payload) error {
 		return err
 	}
 
+	if err := t.db.Receipts.Import(receipts); err != nil {
+		return err
+	}
+
+	if err := t.db.ValidatorProposals.Import(proposals); err != nil {
+		return err
+	}
+
 	for _, h := range payload.Heights {
 		if h.Parsed == nil {
 			continue
@@ -156,7 +177,11 @@ func (t PersistorTask) updateEpochs(payload *Payload) error {
 		for k := range epochsToUpdate {
 			epochIDs = append(epochIDs, k)
 		}
-		if err := t.db.Epochs.UpdateCounts(epochIDs); err != nil {
+		var rewardRate []int64
+		if payload.Genesis != nil {
+			rewardRate = payload.Genesis.ProtocolRewardRate
+		}
+		if err := t.db.Epochs.UpdateCounts(epochIDs, rewardRate); err != nil {
 			return err
 		}
 	}
@@ -165,18 +190,43 @@ func (t PersistorTask) updateEpochs(payload *Payload) error {
 }
 
 func (t PersistorTask) createStats(payload *Payload) error {
-	for _, bucket := range []string{store.BucketHour, store.BucketDay} {
-		t.logger.WithField("bucket", bucket).Debug("creating block stats")
+	heightRange := store.HeightRange{
+		Start: payload.StartHeight,
+		End:   payload.EndHeight,
+	}
 
-		timeRange := store.TimeRange{
-			Start: payload.StartTime,
-			End:   payload.EndTime,
+	t.logger.Debug("creating validator counts")
+	if err := t.db.Stats.CreateValidatorCounts(heightRange); err != nil {
+		return err
+	}
+
+	timeRange := store.TimeRange{
+		Start: payload.StartTime,
+		End:   payload.EndTime,
+	}
+
+	// Week and month transaction stats are rebuilt by the cleanup task
+	for _, bucket := range []string{store.BucketHour, store.BucketDay} {
+		t.logger.WithField("bucket", bucket).Debug("creating transaction stats")
+		if err := t.db.Stats.CreateTransactionStats(bucket, timeRange); err != nil {
+			return err
 		}
+	}
 
+	// Week and month block stats are rebuilt by the cleanup task
+	for _, bucket := range []string{store.BucketMinute, store.BucketHour, store.BucketDay} {
+		t.logger.WithField("bucket", bucket).Debug("creating block stats")
 		if err := t.db.Stats.CreateBlockStats(bucket, timeRange); err != nil {
 			return err
 		}
 	}
 
+	for _, bucket := range []string{store.BucketHour, store.BucketDay} {
+		t.logger.WithField("bucket", bucket).Debug("creating validator stats")
+		if err := t.db.Stats.CreateValidatorsStats(bucket, timeRange); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
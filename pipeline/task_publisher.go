@@ -0,0 +1,53 @@
+package pipeline
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/figment-networks/near-indexer/store"
+)
+
+// PublisherTask notifies the API servers about newly indexed blocks
+type PublisherTask struct {
+	db     *store.Store
+	logger *logrus.Logger
+}
+
+// NewPublisherTask returns a new publisher task
+func NewPublisherTask(db *store.Store, logger *logrus.Logger) PublisherTask {
+	return PublisherTask{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Name returns the task name
+func (t PublisherTask) Name() string {
+	return publisherTaskName
+}
+
+// ShouldRun returns true if there any heights to process
+func (t PublisherTask) ShouldRun(payload *Payload) bool {
+	return len(payload.Heights) > 0
+}
+
+// Run executes the publisher task
+func (t PublisherTask) Run(ctx context.Context, payload *Payload) error {
+	defer logTaskDuration(t, time.Now())
+
+	for _, h := range payload.Heights {
+		if h.Skip || h.Parsed == nil || h.Parsed.Block == nil {
+			continue
+		}
+
+		// Streaming is not critical for the sync process, so errors are only logged
+		if err := t.db.Blocks.NotifyBlock(h.Height); err != nil {
+			t.logger.WithError(err).WithField("height", h.Height).Warn("block notification failed")
+			break
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package pipeline
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/figment-networks/near-indexer/store"
+)
+
+// WebhooksTask creates webhook deliveries for newly indexed events and transactions
+type WebhooksTask struct {
+	db     *store.Store
+	logger *logrus.Logger
+}
+
+// NewWebhooksTask returns a new webhooks task
+func NewWebhooksTask(db *store.Store, logger *logrus.Logger) WebhooksTask {
+	return WebhooksTask{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Name returns the task name
+func (t WebhooksTask) Name() string {
+	return webhooksTaskName
+}
+
+// ShouldRun returns true if there any heights to process
+func (t WebhooksTask) ShouldRun(payload *Payload) bool {
+	return len(payload.Heights) > 0
+}
+
+// Run executes the webhooks task
+func (t WebhooksTask) Run(ctx context.Context, payload *Payload) error {
+	defer logTaskDuration(t, time.Now())
+
+	heightRange := store.HeightRange{
+		Start: payload.StartHeight,
+		End:   payload.EndHeight,
+	}
+
+	return t.db.Webhooks.EnqueueDeliveries(heightRange)
+}
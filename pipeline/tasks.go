@@ -12,6 +12,8 @@ const (
 	parserTaskName    = "parser"
 	persistorTaskName = "persistor"
 	analyzerTaskName  = "analyzer"
+	publisherTaskName = "publisher"
+	webhooksTaskName  = "webhooks"
 )
 
 type Task interface {
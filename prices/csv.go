@@ -0,0 +1,83 @@
+package prices
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	csvDateFormat = "2006-01-02"
+)
+
+// CSVSource provides daily prices loaded from a CSV file.
+// The file must contain the date (YYYY-MM-DD), currency and price columns.
+type CSVSource struct {
+	prices map[string]float64
+}
+
+// NewCSVSource returns a new price source from the CSV input
+func NewCSVSource(input io.Reader) (*CSVSource, error) {
+	reader := csv.NewReader(input)
+	reader.TrimLeadingSpace = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	src := &CSVSource{
+		prices: map[string]float64{},
+	}
+
+	for idx, row := range rows {
+		if len(row) != 3 {
+			return nil, fmt.Errorf("invalid number of columns on line %d", idx+1)
+		}
+		if idx == 0 && strings.ToLower(row[0]) == "date" {
+			continue
+		}
+
+		date, err := time.Parse(csvDateFormat, row[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid date on line %d: %v", idx+1, err)
+		}
+
+		price, err := strconv.ParseFloat(row[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid price on line %d: %v", idx+1, err)
+		}
+
+		src.prices[priceKey(row[1], date)] = price
+	}
+
+	return src, nil
+}
+
+// NewCSVFileSource returns a new price source from the CSV file
+func NewCSVFileSource(path string) (*CSVSource, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return NewCSVSource(f)
+}
+
+// Price returns the daily price for a given time
+func (s *CSVSource) Price(currency string, t time.Time) (float64, error) {
+	price, ok := s.prices[priceKey(currency, t)]
+	if !ok {
+		return 0, ErrPriceNotFound
+	}
+	return price, nil
+}
+
+func priceKey(currency string, t time.Time) string {
+	return strings.ToUpper(currency) + ":" + t.UTC().Format(csvDateFormat)
+}
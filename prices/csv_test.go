@@ -0,0 +1,44 @@
+package prices
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSVSource(t *testing.T) {
+	input := strings.NewReader("date,currency,price\n2021-01-01,USD,1.23\n2021-01-02,usd,1.5\n2021-01-01,EUR,1.1\n")
+
+	src, err := NewCSVSource(input)
+	assert.NoError(t, err)
+
+	ts := time.Date(2021, 1, 1, 15, 30, 0, 0, time.UTC)
+
+	price, err := src.Price("USD", ts)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.23, price)
+
+	price, err = src.Price("eur", ts)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.1, price)
+
+	price, err = src.Price("USD", ts.Add(time.Hour*24))
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, price)
+
+	_, err = src.Price("USD", ts.Add(time.Hour*48))
+	assert.Equal(t, ErrPriceNotFound, err)
+}
+
+func TestCSVSourceInvalid(t *testing.T) {
+	_, err := NewCSVSource(strings.NewReader("2021-01-01,USD\n"))
+	assert.Error(t, err)
+
+	_, err = NewCSVSource(strings.NewReader("2021-01-01,USD,abc\n"))
+	assert.Error(t, err)
+
+	_, err = NewCSVSource(strings.NewReader("01/01/2021,USD,1.0\n"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,17 @@
+package prices
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrPriceNotFound is returned when the price is not available for the given date
+	ErrPriceNotFound = errors.New("price not found")
+)
+
+// Source provides historical NEAR token prices
+type Source interface {
+	// Price returns the token price in a fiat currency for the given time
+	Price(currency string, t time.Time) (float64, error)
+}
@@ -0,0 +1,46 @@
+package rosetta
+
+// Error is a Rosetta API error
+type Error struct {
+	Code      int32                  `json:"code"`
+	Message   string                 `json:"message"`
+	Retriable bool                   `json:"retriable"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// WithDetails returns a copy of the error with a detail message attached
+func (e *Error) WithDetails(err error) *Error {
+	return &Error{
+		Code:      e.Code,
+		Message:   e.Message,
+		Retriable: e.Retriable,
+		Details:   map[string]interface{}{"error": err.Error()},
+	}
+}
+
+var (
+	ErrRequestInvalid         = &Error{Code: 1, Message: "Request is invalid"}
+	ErrNetworkNotSupported    = &Error{Code: 2, Message: "Network is not supported"}
+	ErrBlockNotFound          = &Error{Code: 3, Message: "Block not found"}
+	ErrTransactionNotFound    = &Error{Code: 4, Message: "Transaction not found"}
+	ErrAccountNotFound        = &Error{Code: 5, Message: "Account not found"}
+	ErrNodeUnavailable        = &Error{Code: 6, Message: "Node is unavailable", Retriable: true}
+	ErrInternal               = &Error{Code: 7, Message: "Internal error", Retriable: true}
+	ErrMempoolTransactionGone = &Error{Code: 8, Message: "Transaction not found in mempool"}
+
+	// Errors contains all errors returned by the API
+	Errors = []*Error{
+		ErrRequestInvalid,
+		ErrNetworkNotSupported,
+		ErrBlockNotFound,
+		ErrTransactionNotFound,
+		ErrAccountNotFound,
+		ErrNodeUnavailable,
+		ErrInternal,
+		ErrMempoolTransactionGone,
+	}
+)
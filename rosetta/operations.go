@@ -0,0 +1,272 @@
+package rosetta
+
+import (
+	"encoding/json"
+
+	"github.com/figment-networks/near-indexer/model"
+	"github.com/figment-networks/near-indexer/model/types"
+	"github.com/figment-networks/near-indexer/near"
+)
+
+const (
+	OpTransfer       = "TRANSFER"
+	OpFunctionCall   = "FUNCTION_CALL"
+	OpStake          = "STAKE"
+	OpCreateAccount  = "CREATE_ACCOUNT"
+	OpDeployContract = "DEPLOY_CONTRACT"
+	OpAddKey         = "ADD_KEY"
+	OpDeleteKey      = "DELETE_KEY"
+	OpDeleteAccount  = "DELETE_ACCOUNT"
+	OpFee            = "FEE"
+
+	StatusSuccess = "SUCCESS"
+	StatusFailure = "FAILURE"
+)
+
+var (
+	// OperationTypes contains all supported operation types
+	OperationTypes = []string{
+		OpTransfer,
+		OpFunctionCall,
+		OpStake,
+		OpCreateAccount,
+		OpDeployContract,
+		OpAddKey,
+		OpDeleteKey,
+		OpDeleteAccount,
+		OpFee,
+	}
+
+	// OperationStatuses contains all supported operation statuses
+	OperationStatuses = []*OperationStatus{
+		{Status: StatusSuccess, Successful: true},
+		{Status: StatusFailure, Successful: false},
+	}
+
+	actionOperations = map[string]string{
+		near.ActionTransfer:       OpTransfer,
+		near.ActionFunctionCall:   OpFunctionCall,
+		near.ActionStake:          OpStake,
+		near.ActionCreateAccount:  OpCreateAccount,
+		near.ActionDeployContract: OpDeployContract,
+		near.ActionAddKey:         OpAddKey,
+		near.ActionDeleteKey:      OpDeleteKey,
+		near.ActionDeleteAccount:  OpDeleteAccount,
+	}
+)
+
+// AccountLookup returns the state of an account before the transaction execution
+type AccountLookup func(account string) (*near.Account, error)
+
+type storedAction struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// NewBlock returns a Rosetta block for the indexed block and its transactions
+func NewBlock(block *model.Block, parent *BlockIdentifier, transactions []model.Transaction, lookup AccountLookup) (*Block, error) {
+	result := &Block{
+		BlockIdentifier:       NewBlockIdentifier(block),
+		ParentBlockIdentifier: parent,
+		Timestamp:             block.Time.UnixNano() / 1e6,
+		Transactions:          make([]*Transaction, len(transactions)),
+	}
+
+	for i := range transactions {
+		tx, err := NewTransaction(&transactions[i], lookup)
+		if err != nil {
+			return nil, err
+		}
+		result.Transactions[i] = tx
+	}
+
+	return result, nil
+}
+
+// NewBlockIdentifier returns a block identifier for the indexed block
+func NewBlockIdentifier(block *model.Block) *BlockIdentifier {
+	return &BlockIdentifier{
+		Index: int64(block.ID),
+		Hash:  block.Hash,
+	}
+}
+
+// NewTransaction returns a Rosetta transaction for the indexed transaction
+func NewTransaction(tx *model.Transaction, lookup AccountLookup) (*Transaction, error) {
+	operations, err := Operations(tx, lookup)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Transaction{
+		TransactionIdentifier: &TransactionIdentifier{Hash: tx.Hash},
+		Operations:            operations,
+	}, nil
+}
+
+// Operations maps transaction actions to Rosetta operations.
+//
+// Transfers and function call deposits produce a debit of the signer and a credit
+// of the receiver. Stake debits the liquid balance of the signer by the increase
+// of its locked balance, while a decreased stake is only unlocked at the end of
+// the epoch and reported without an amount. Account deletion debits the remaining
+// balance of the receiver and credits the beneficiary. Balances before the
+// transaction are fetched with the lookup. Key and other account management
+// actions do not move funds. Operations of failed transactions have a failure
+// status and no amounts, while the gas fee is always charged.
+func Operations(tx *model.Transaction, lookup AccountLookup) ([]*Operation, error) {
+	actions := []storedAction{}
+	if len(tx.Actions) > 0 {
+		if err := json.Unmarshal(tx.Actions, &actions); err != nil {
+			return nil, err
+		}
+	}
+
+	status := StatusSuccess
+	if !tx.Success {
+		status = StatusFailure
+	}
+
+	ops := []*Operation{}
+	add := func(op *Operation) *Operation {
+		op.OperationIdentifier = &OperationIdentifier{Index: int64(len(ops))}
+		ops = append(ops, op)
+		return op
+	}
+
+	// Deposits of the earlier actions are credited to the receiver before its deletion
+	received := types.NewInt64Amount(0)
+
+	var locked *types.Amount
+
+	for _, action := range actions {
+		opType, ok := actionOperations[action.Type]
+		if !ok {
+			continue
+		}
+
+		var deposit types.Amount
+		var beneficiary string
+		metadata := map[string]interface{}{}
+
+		switch action.Type {
+		case near.ActionTransfer:
+			data := near.TransferAction{}
+			if err := json.Unmarshal(action.Data, &data); err != nil {
+				return nil, err
+			}
+			deposit = types.NewAmount(data.Deposit)
+		case near.ActionFunctionCall:
+			data := near.FunctionCallAction{}
+			if err := json.Unmarshal(action.Data, &data); err != nil {
+				return nil, err
+			}
+			deposit = types.NewAmount(data.Deposit)
+			metadata["method_name"] = data.MethodName
+		case near.ActionStake:
+			data := near.StakeAction{}
+			if err := json.Unmarshal(action.Data, &data); err != nil {
+				return nil, err
+			}
+			metadata["stake"] = data.Amount
+			metadata["public_key"] = data.PublicKey
+
+			if !tx.Success {
+				break
+			}
+			if locked == nil {
+				account, err := lookup(tx.Sender)
+				if err != nil {
+					return nil, err
+				}
+				previous := types.NewAmount(account.Locked)
+				locked = &previous
+			}
+
+			stake := types.NewAmount(data.Amount)
+			if stake.Compare(*locked) > 0 {
+				add(&Operation{
+					Type:     opType,
+					Status:   status,
+					Account:  &AccountIdentifier{Address: tx.Sender},
+					Amount:   newAmount(locked.Sub(stake)),
+					Metadata: metadata,
+				})
+				locked = &stake
+				continue
+			}
+		case near.ActionDeleteAccount:
+			data := near.DeleteAccountAction{}
+			if err := json.Unmarshal(action.Data, &data); err != nil {
+				return nil, err
+			}
+			metadata["beneficiary_id"] = data.BeneficiaryID
+
+			if !tx.Success {
+				break
+			}
+			account, err := lookup(tx.Receiver)
+			if err != nil {
+				return nil, err
+			}
+			deposit = types.NewAmount(account.Amount).Add(received)
+			beneficiary = data.BeneficiaryID
+		}
+
+		if len(metadata) == 0 {
+			metadata = nil
+		}
+
+		if deposit.Int == nil || deposit.Sign() == 0 {
+			add(&Operation{
+				Type:     opType,
+				Status:   status,
+				Account:  &AccountIdentifier{Address: tx.Sender},
+				Metadata: metadata,
+			})
+			continue
+		}
+
+		// Deleted account balance moves from the receiver to the beneficiary
+		from, to := tx.Sender, tx.Receiver
+		if beneficiary != "" {
+			from, to = tx.Receiver, beneficiary
+		} else {
+			received = received.Add(deposit)
+		}
+
+		debit := add(&Operation{
+			Type:     opType,
+			Status:   status,
+			Account:  &AccountIdentifier{Address: from},
+			Amount:   newAmount(types.NewInt64Amount(0).Sub(deposit)),
+			Metadata: metadata,
+		})
+		add(&Operation{
+			RelatedOperations: []*OperationIdentifier{debit.OperationIdentifier},
+			Type:              opType,
+			Status:            status,
+			Account:           &AccountIdentifier{Address: to},
+			Amount:            newAmount(deposit),
+			Metadata:          metadata,
+		})
+	}
+
+	if tx.Fee.Int != nil && tx.Fee.Sign() > 0 {
+		add(&Operation{
+			Type:    OpFee,
+			Status:  StatusSuccess,
+			Account: &AccountIdentifier{Address: tx.Sender},
+			Amount:  newAmount(types.NewInt64Amount(0).Sub(tx.Fee)),
+		})
+	}
+
+	return ops, nil
+}
+
+func newAmount(value types.Amount) *Amount {
+	return &Amount{
+		Value:    value.String(),
+		Currency: Currency,
+	}
+}
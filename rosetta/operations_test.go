@@ -0,0 +1,137 @@
+package rosetta
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/figment-networks/near-indexer/model"
+	"github.com/figment-networks/near-indexer/model/types"
+	"github.com/figment-networks/near-indexer/near"
+)
+
+func TestOperations(t *testing.T) {
+	tx := &model.Transaction{
+		Hash:     "hash",
+		Sender:   "alice",
+		Receiver: "bob",
+		Fee:      types.NewAmount("100"),
+		Success:  true,
+		Actions: json.RawMessage(`[
+			{"type": "Transfer", "data": {"deposit": "1000"}},
+			{"type": "FunctionCall", "data": {"method_name": "deposit", "deposit": "50", "gas": 1}},
+			{"type": "FunctionCall", "data": {"method_name": "ping", "deposit": "0", "gas": 1}},
+			{"type": "Stake", "data": {"stake": "5000", "public_key": "key"}}
+		]`),
+	}
+
+	lookup := func(account string) (*near.Account, error) {
+		assert.Equal(t, "alice", account)
+		return &near.Account{Amount: "10000", Locked: "1000"}, nil
+	}
+
+	ops, err := Operations(tx, lookup)
+	assert.NoError(t, err)
+	assert.Len(t, ops, 7)
+
+	for i, op := range ops {
+		assert.Equal(t, int64(i), op.OperationIdentifier.Index)
+	}
+
+	assert.Equal(t, OpTransfer, ops[0].Type)
+	assert.Equal(t, "alice", ops[0].Account.Address)
+	assert.Equal(t, "-1000", ops[0].Amount.Value)
+	assert.Equal(t, "bob", ops[1].Account.Address)
+	assert.Equal(t, "1000", ops[1].Amount.Value)
+	assert.Equal(t, int64(0), ops[1].RelatedOperations[0].Index)
+
+	assert.Equal(t, OpFunctionCall, ops[2].Type)
+	assert.Equal(t, "-50", ops[2].Amount.Value)
+	assert.Equal(t, "50", ops[3].Amount.Value)
+	assert.Equal(t, "deposit", ops[3].Metadata["method_name"])
+
+	assert.Equal(t, OpFunctionCall, ops[4].Type)
+	assert.Nil(t, ops[4].Amount)
+
+	assert.Equal(t, OpStake, ops[5].Type)
+	assert.Equal(t, "alice", ops[5].Account.Address)
+	assert.Equal(t, "-4000", ops[5].Amount.Value)
+	assert.Equal(t, "5000", ops[5].Metadata["stake"])
+
+	assert.Equal(t, OpFee, ops[6].Type)
+	assert.Equal(t, "-100", ops[6].Amount.Value)
+	assert.Equal(t, StatusSuccess, ops[6].Status)
+}
+
+func TestOperationsFailed(t *testing.T) {
+	tx := &model.Transaction{
+		Sender:   "alice",
+		Receiver: "bob",
+		Fee:      types.NewAmount("100"),
+		Success:  false,
+		Actions:  json.RawMessage(`[{"type": "Transfer", "data": {"deposit": "1000"}}]`),
+	}
+
+	ops, err := Operations(tx, nil)
+	assert.NoError(t, err)
+	assert.Len(t, ops, 3)
+	assert.Equal(t, StatusFailure, ops[0].Status)
+	assert.Equal(t, StatusFailure, ops[1].Status)
+	assert.Equal(t, StatusSuccess, ops[2].Status)
+}
+
+func TestOperationsStakeDecrease(t *testing.T) {
+	tx := &model.Transaction{
+		Sender:   "alice",
+		Receiver: "alice",
+		Success:  true,
+		Actions: json.RawMessage(`[
+			{"type": "Stake", "data": {"stake": "3000", "public_key": "key"}},
+			{"type": "Stake", "data": {"stake": "8000", "public_key": "key"}}
+		]`),
+	}
+
+	lookups := 0
+	lookup := func(account string) (*near.Account, error) {
+		lookups++
+		return &near.Account{Amount: "10000", Locked: "5000"}, nil
+	}
+
+	ops, err := Operations(tx, lookup)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, lookups)
+	assert.Len(t, ops, 2)
+	assert.Nil(t, ops[0].Amount)
+	assert.Equal(t, "-3000", ops[1].Amount.Value)
+}
+
+func TestOperationsDeleteAccount(t *testing.T) {
+	tx := &model.Transaction{
+		Sender:   "alice",
+		Receiver: "bob",
+		Fee:      types.NewAmount("100"),
+		Success:  true,
+		Actions: json.RawMessage(`[
+			{"type": "Transfer", "data": {"deposit": "100"}},
+			{"type": "DeleteAccount", "data": {"beneficiary_id": "carol"}}
+		]`),
+	}
+
+	lookup := func(account string) (*near.Account, error) {
+		assert.Equal(t, "bob", account)
+		return &near.Account{Amount: "900"}, nil
+	}
+
+	ops, err := Operations(tx, lookup)
+	assert.NoError(t, err)
+	assert.Len(t, ops, 5)
+
+	assert.Equal(t, OpDeleteAccount, ops[2].Type)
+	assert.Equal(t, "bob", ops[2].Account.Address)
+	assert.Equal(t, "-1000", ops[2].Amount.Value)
+	assert.Equal(t, "carol", ops[3].Account.Address)
+	assert.Equal(t, "1000", ops[3].Amount.Value)
+	assert.Equal(t, int64(2), ops[3].RelatedOperations[0].Index)
+	assert.Equal(t, "carol", ops[3].Metadata["beneficiary_id"])
+}
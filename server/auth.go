@@ -0,0 +1,296 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/figment-networks/near-indexer/config"
+	"github.com/figment-networks/near-indexer/model"
+	"github.com/figment-networks/near-indexer/store"
+)
+
+const (
+	apiKeyHeader    = "X-API-Key"
+	apiKeyParam     = "api_key"
+	apiKeyCacheTTL  = time.Minute
+	apiKeyCacheSize = 10000
+	adminPathPrefix = "/admin/"
+
+	// API key lookups of a client IP that miss the cache, i.e. unknown keys
+	// and first requests of known keys, are limited to prevent key guessing
+	apiKeyLookupRate  = 1.0
+	apiKeyLookupBurst = 20
+
+	// apiKeyContextKey is the gin context key of the request API key
+	apiKeyContextKey = "api_key"
+)
+
+// apiAuth authenticates API requests and enforces rate limits and quotas.
+// Requests with an API key are limited per key, anonymous requests per client IP.
+type apiAuth struct {
+	db             *store.Store
+	required       bool
+	trustedProxies []*net.IPNet
+
+	ipRate   float64
+	ipBurst  int
+	keyRate  float64
+	keyBurst int
+
+	keys     *apiKeyCache
+	limiters *rateLimiters
+	usage    *usageTracker
+}
+
+// apiKeyCache keeps recently used API keys to avoid a lookup on every request
+type apiKeyCache struct {
+	mu    sync.Mutex
+	items map[string]apiKeyCacheEntry
+}
+
+type apiKeyCacheEntry struct {
+	key     *model.APIKey
+	expires time.Time
+}
+
+func newAPIAuth(cfg *config.Config, db *store.Store, logger *logrus.Logger) *apiAuth {
+	return &apiAuth{
+		db:             db,
+		required:       cfg.APIKeysRequired,
+		trustedProxies: cfg.TrustedProxyNetworks(),
+		ipRate:         cfg.RateLimitIP,
+		ipBurst:        cfg.RateLimitIPBurst,
+		keyRate:        cfg.RateLimitKey,
+		keyBurst:       cfg.RateLimitKeyBurst,
+		keys:           &apiKeyCache{items: map[string]apiKeyCacheEntry{}},
+		limiters:       newRateLimiters(),
+		usage:          newUsageTracker(db.APIKeys, logger),
+	}
+}
+
+// handler returns the authentication middleware
+func (a *apiAuth) handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Healthchecks must not depend on the API keys
+		if c.Request.URL.Path == "/health" {
+			return
+		}
+
+		now := time.Now()
+		ip := clientIP(c.Request, a.trustedProxies)
+		adminPath := strings.HasPrefix(c.Request.URL.Path, adminPathPrefix)
+
+		token := c.GetHeader(apiKeyHeader)
+		if token == "" {
+			token = c.Query(apiKeyParam)
+		}
+
+		if token == "" {
+			if a.required || adminPath {
+				a.usage.unauthorized()
+				jsonError(c, http.StatusUnauthorized, "api key is required")
+				return
+			}
+
+			if ok, wait := a.limiters.allow("ip:"+ip, a.ipRate, a.ipBurst, now); !ok {
+				a.usage.reject(nil, now)
+				tooManyRequests(c, wait, "rate limit exceeded")
+				return
+			}
+
+			a.usage.anonymous()
+			return
+		}
+
+		key, ok := a.cachedKey(token, now)
+		if !ok {
+			if ok, wait := a.limiters.allow("lookup:"+ip, apiKeyLookupRate, apiKeyLookupBurst, now); !ok {
+				a.usage.unauthorized()
+				tooManyRequests(c, wait, "too many api key lookups")
+				return
+			}
+
+			var err error
+			if key, err = a.findKey(token, now); err != nil {
+				serverError(c, err)
+				return
+			}
+		}
+		if key == nil || !key.Active {
+			a.usage.unauthorized()
+			jsonError(c, http.StatusUnauthorized, "api key is invalid")
+			return
+		}
+		if adminPath && !key.Admin {
+			a.usage.unauthorized()
+			jsonError(c, http.StatusForbidden, "api key does not have admin access")
+			return
+		}
+
+		limit, burst := a.keyRate, a.keyBurst
+		if key.RateLimit > 0 {
+			limit = key.RateLimit
+		}
+		if key.RateBurst > 0 {
+			burst = key.RateBurst
+		}
+
+		if ok, wait := a.limiters.allow("key:"+strconv.FormatInt(key.ID, 10), limit, burst, now); !ok {
+			a.usage.reject(key, now)
+			tooManyRequests(c, wait, "rate limit exceeded")
+			return
+		}
+
+		used, ok, err := a.usage.track(key, now)
+		if err != nil {
+			serverError(c, err)
+			return
+		}
+
+		if key.DailyQuota > 0 {
+			remaining := key.DailyQuota - used
+			if remaining < 0 {
+				remaining = 0
+			}
+			c.Header("X-Quota-Limit", strconv.FormatInt(key.DailyQuota, 10))
+			c.Header("X-Quota-Remaining", strconv.FormatInt(remaining, 10))
+		}
+
+		if !ok {
+			tomorrow := usageDay(now).Add(24 * time.Hour)
+			tooManyRequests(c, tomorrow.Sub(now), "daily quota exceeded")
+			return
+		}
+
+		c.Set(apiKeyContextKey, key)
+	}
+}
+
+// requestAPIKey returns the API key of the request, or nil for anonymous requests
+func requestAPIKey(c *gin.Context) *model.APIKey {
+	if val, ok := c.Get(apiKeyContextKey); ok {
+		return val.(*model.APIKey)
+	}
+	return nil
+}
+
+// requireAPIKey rejects anonymous requests
+func requireAPIKey(c *gin.Context) {
+	if requestAPIKey(c) == nil {
+		jsonError(c, http.StatusUnauthorized, "api key is required")
+	}
+}
+
+// cachedKey returns a recently used API key for the token
+func (a *apiAuth) cachedKey(token string, now time.Time) (*model.APIKey, bool) {
+	a.keys.mu.Lock()
+	defer a.keys.mu.Unlock()
+
+	entry, ok := a.keys.items[token]
+	if !ok || !now.Before(entry.expires) {
+		return nil, false
+	}
+	return entry.key, true
+}
+
+// findKey returns an API key for the token, or nil if the key does not exist
+func (a *apiAuth) findKey(token string, now time.Time) (*model.APIKey, error) {
+	key, err := a.db.APIKeys.FindByKey(token)
+	if err == store.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	a.keys.mu.Lock()
+	defer a.keys.mu.Unlock()
+
+	// Unknown keys are not cached, so random tokens can't evict the existing keys.
+	// Reset the cache when it gets too large anyway.
+	if len(a.keys.items) >= apiKeyCacheSize {
+		a.keys.items = map[string]apiKeyCacheEntry{}
+	}
+	a.keys.items[token] = apiKeyCacheEntry{key: key, expires: now.Add(apiKeyCacheTTL)}
+
+	return key, nil
+}
+
+// tooManyRequests renders a HTTP 429 response with a Retry-After header
+func tooManyRequests(c *gin.Context, wait time.Duration, message string) {
+	c.Header("Retry-After", fmt.Sprintf("%d", int64(math.Ceil(wait.Seconds()))))
+	jsonError(c, http.StatusTooManyRequests, message)
+}
+
+// apiKeyUsage contains the API key details along with its daily usage
+type apiKeyUsage struct {
+	model.APIKey
+	Usage []model.APIKeyUsage `json:"usage"`
+}
+
+// apiUsageReport contains the usage of all API keys and the server request counters
+type apiUsageReport struct {
+	Since  string        `json:"since"`
+	Keys   []apiKeyUsage `json:"keys"`
+	Server usageStats    `json:"server"`
+}
+
+// GetAdminUsage renders the daily usage of all API keys
+func (s Server) GetAdminUsage(c *gin.Context) {
+	params := adminUsageParams{}
+	if err := c.BindQuery(&params); err != nil {
+		badRequest(c, err)
+		return
+	}
+	params.setDefaults()
+
+	// Include the requests that have not been flushed yet
+	if err := s.auth.usage.flush(); err != nil {
+		serverError(c, err)
+		return
+	}
+
+	keys, err := s.db.APIKeys.All()
+	if shouldReturn(c, err) {
+		return
+	}
+
+	since := usageDay(time.Now()).AddDate(0, 0, -int(params.Days-1))
+	usage, err := s.db.APIKeys.Usage(since)
+	if shouldReturn(c, err) {
+		return
+	}
+
+	usageByKey := map[int64][]model.APIKeyUsage{}
+	for _, item := range usage {
+		usageByKey[item.APIKeyID] = append(usageByKey[item.APIKeyID], item)
+	}
+
+	result := []apiKeyUsage{}
+	for _, key := range keys {
+		if params.KeyID > 0 && key.ID != params.KeyID {
+			continue
+		}
+
+		keyUsage := usageByKey[key.ID]
+		if keyUsage == nil {
+			keyUsage = []model.APIKeyUsage{}
+		}
+		result = append(result, apiKeyUsage{APIKey: key, Usage: keyUsage})
+	}
+
+	jsonOk(c, apiUsageReport{
+		Since:  since.Format("2006-01-02"),
+		Keys:   result,
+		Server: s.auth.usage.Stats(),
+	})
+}
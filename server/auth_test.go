@@ -0,0 +1,142 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/figment-networks/near-indexer/model"
+)
+
+func testAuthRouter(required bool, keys map[string]*model.APIKey) *gin.Engine {
+	auth := &apiAuth{
+		required: required,
+		ipRate:   1,
+		ipBurst:  1,
+		keyRate:  100,
+		keyBurst: 100,
+		keys:     &apiKeyCache{items: map[string]apiKeyCacheEntry{}},
+		limiters: newRateLimiters(),
+		usage:    newUsageTracker(newFixtureUsage(), logrus.New()),
+	}
+
+	// Keys are served from the cache, without a database lookup
+	for token, key := range keys {
+		auth.keys.items[token] = apiKeyCacheEntry{key: key, expires: time.Now().Add(time.Hour)}
+	}
+
+	router := gin.New()
+	router.Use(auth.handler())
+	router.GET("/blocks", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/admin/usage", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return router
+}
+
+func TestAPIAuthHandler(t *testing.T) {
+	limited := testAPIKey(1, 0)
+	limited.Active = true
+	limited.RateLimit = 1
+	limited.RateBurst = 1
+
+	quota := testAPIKey(2, 2)
+	quota.Active = true
+
+	inactive := testAPIKey(3, 0)
+
+	admin := testAPIKey(4, 0)
+	admin.Active = true
+	admin.Admin = true
+
+	keys := map[string]*model.APIKey{
+		"limited":  limited,
+		"quota":    quota,
+		"inactive": inactive,
+		"admin":    admin,
+	}
+
+	type request struct {
+		path       string
+		token      string
+		status     int
+		retryAfter string
+		remaining  string
+	}
+
+	examples := []struct {
+		name     string
+		required bool
+		requests []request
+	}{
+		{
+			name: "anonymous",
+			requests: []request{
+				{path: "/blocks", status: http.StatusOK},
+				{path: "/blocks", status: http.StatusTooManyRequests, retryAfter: "1"},
+				{path: "/blocks", token: "admin", status: http.StatusOK},
+			},
+		},
+		{
+			name:     "required",
+			required: true,
+			requests: []request{
+				{path: "/blocks", status: http.StatusUnauthorized},
+				{path: "/blocks", token: "admin", status: http.StatusOK},
+			},
+		},
+		{
+			name: "rate limit",
+			requests: []request{
+				{path: "/blocks", token: "limited", status: http.StatusOK},
+				{path: "/blocks", token: "limited", status: http.StatusTooManyRequests, retryAfter: "1"},
+			},
+		},
+		{
+			name: "quota",
+			requests: []request{
+				{path: "/blocks", token: "quota", status: http.StatusOK, remaining: "1"},
+				{path: "/blocks", token: "quota", status: http.StatusOK, remaining: "0"},
+				{path: "/blocks", token: "quota", status: http.StatusTooManyRequests, remaining: "0"},
+			},
+		},
+		{
+			name: "inactive",
+			requests: []request{
+				{path: "/blocks", token: "inactive", status: http.StatusUnauthorized},
+			},
+		},
+		{
+			name: "admin",
+			requests: []request{
+				{path: "/admin/usage", status: http.StatusUnauthorized},
+				{path: "/admin/usage", token: "quota", status: http.StatusForbidden},
+				{path: "/admin/usage", token: "admin", status: http.StatusOK},
+			},
+		},
+	}
+
+	for _, ex := range examples {
+		t.Run(ex.name, func(t *testing.T) {
+			router := testAuthRouter(ex.required, keys)
+
+			for i, req := range ex.requests {
+				r := httptest.NewRequest(http.MethodGet, req.path, nil)
+				if req.token != "" {
+					r.Header.Set(apiKeyHeader, req.token)
+				}
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, r)
+
+				assert.Equal(t, req.status, w.Code, "request %d", i)
+				if req.retryAfter != "" {
+					assert.Equal(t, req.retryAfter, w.Header().Get("Retry-After"), "request %d", i)
+				}
+				assert.Equal(t, req.remaining, w.Header().Get("X-Quota-Remaining"), "request %d", i)
+			}
+		})
+	}
+}
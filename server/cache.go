@@ -0,0 +1,271 @@
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/figment-networks/near-indexer/store"
+)
+
+const (
+	// cacheMaxEntries limits the number of cached responses
+	cacheMaxEntries = 1000
+
+	// cacheHeightInterval is the min interval between the last block lookups,
+	// used when block notifications are not available
+	cacheHeightInterval = time.Second
+
+	// cacheStoreContextKey is the gin context key of the store used by the cached routes
+	cacheStoreContextKey = "cache_store"
+)
+
+var (
+	// cacheTTLs defines the max time a route response is cached for
+	cacheTTLs = map[string]time.Duration{
+		"/validators":  time.Minute,
+		"/epochs":      time.Minute,
+		"/block_stats": 30 * time.Second,
+		"/block_times": 30 * time.Second,
+	}
+)
+
+// responseCache keeps successful responses of the heavy routes in memory. All
+// cached responses are invalidated once a new block is indexed. The cache height
+// follows the primary database, so the cached routes read from the primary too,
+// a lagging replica would fill the cache with the previous block data. When API keys
+// are required, responses are marked private so shared caches don't serve them
+// to clients without a key.
+type responseCache struct {
+	db        *store.Store
+	ttls      map[string]time.Duration
+	notifying func() bool
+	private   bool
+
+	mu              sync.Mutex
+	entries         map[string]*cacheEntry
+	height          uint64
+	heightCheckedAt time.Time
+}
+
+type cacheEntry struct {
+	body        []byte
+	contentType string
+	etag        string
+	height      uint64
+	expires     time.Time
+}
+
+// cacheWriter buffers the response body, so headers could be set once the handler is done
+type cacheWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *cacheWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *cacheWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func newResponseCache(db *store.Store, ttls map[string]time.Duration, notifying func() bool, private bool) *responseCache {
+	return &responseCache{
+		db:        db,
+		ttls:      ttls,
+		notifying: notifying,
+		private:   private,
+		entries:   map[string]*cacheEntry{},
+	}
+}
+
+// SetHeight invalidates all cached responses when the height changes
+func (rc *responseCache) SetHeight(height uint64) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.setHeight(height)
+}
+
+func (rc *responseCache) setHeight(height uint64) {
+	if height > rc.height {
+		rc.height = height
+		rc.entries = map[string]*cacheEntry{}
+	}
+}
+
+// currentHeight returns the last indexed height. Height is updated by the block
+// notifications, with a fallback to the last block lookup.
+func (rc *responseCache) currentHeight(now time.Time) uint64 {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if rc.notifying() || now.Sub(rc.heightCheckedAt) < cacheHeightInterval {
+		return rc.height
+	}
+	rc.heightCheckedAt = now
+
+	if block, err := rc.db.Blocks.Last(); err == nil {
+		rc.setHeight(uint64(block.ID))
+	}
+
+	return rc.height
+}
+
+func (rc *responseCache) get(key string, now time.Time) *cacheEntry {
+	height := rc.currentHeight(now)
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	entry := rc.entries[key]
+	if entry == nil || entry.height != height || now.After(entry.expires) {
+		return nil
+	}
+	return entry
+}
+
+func (rc *responseCache) set(key string, entry *cacheEntry, now time.Time) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	// Response was produced before a new block got indexed
+	if entry.height != rc.height {
+		return
+	}
+
+	if len(rc.entries) >= cacheMaxEntries {
+		for k, e := range rc.entries {
+			if now.After(e.expires) {
+				delete(rc.entries, k)
+			}
+		}
+	}
+	if len(rc.entries) >= cacheMaxEntries {
+		return
+	}
+
+	rc.entries[key] = entry
+}
+
+// handler returns the caching middleware
+func (rc *responseCache) handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ttl, ok := rc.ttls[c.FullPath()]
+		if !ok || c.Request.Method != http.MethodGet {
+			return
+		}
+
+		now := time.Now()
+		key := cacheKey(c)
+
+		if entry := rc.get(key, now); entry != nil {
+			c.Header("X-Cache", "HIT")
+			rc.write(c, entry, now)
+			c.Abort()
+			return
+		}
+
+		height := rc.currentHeight(now)
+		c.Set(cacheStoreContextKey, rc.db)
+
+		writer := &cacheWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		if c.Writer.Status() != http.StatusOK {
+			c.Writer.Write(writer.body.Bytes())
+			return
+		}
+
+		entry := &cacheEntry{
+			body:        writer.body.Bytes(),
+			contentType: c.Writer.Header().Get("Content-Type"),
+			etag:        etag(writer.body.Bytes()),
+			height:      height,
+			expires:     now.Add(ttl),
+		}
+		rc.set(key, entry, now)
+
+		c.Header("X-Cache", "MISS")
+		rc.write(c, entry, now)
+	}
+}
+
+// write renders the cached response, or a HTTP 304 response if the client
+// already has the same version.
+func (rc *responseCache) write(c *gin.Context, entry *cacheEntry, now time.Time) {
+	maxAge := int64(math.Ceil(entry.expires.Sub(now).Seconds()))
+	if maxAge < 0 {
+		maxAge = 0
+	}
+
+	scope := "public"
+	if rc.private {
+		scope = "private"
+	}
+
+	c.Header("ETag", entry.etag)
+	c.Header("Cache-Control", fmt.Sprintf("%s, max-age=%d", scope, maxAge))
+	c.Header("Vary", apiKeyHeader)
+
+	if etagMatch(c.GetHeader("If-None-Match"), entry.etag) {
+		c.Status(http.StatusNotModified)
+		c.Writer.WriteHeaderNow()
+		return
+	}
+
+	c.Data(http.StatusOK, entry.contentType, entry.body)
+}
+
+// cacheKey returns the request path along with sorted query params, excluding the API key.
+// Responses of the cached routes do not depend on the API key, so a cached response is
+// shared by all keys. Authentication, rate limits and quotas run before the cache,
+// so every request is still checked and counted against its own key.
+func cacheKey(c *gin.Context) string {
+	query := c.Request.URL.Query()
+	query.Del(apiKeyParam)
+
+	if len(query) == 0 {
+		return c.Request.URL.Path
+	}
+	return c.Request.URL.Path + "?" + query.Encode()
+}
+
+// requestStore returns the store of the cached route, or the default store
+func (s Server) requestStore(c *gin.Context) *store.Store {
+	if val, ok := c.Get(cacheStoreContextKey); ok {
+		return val.(*store.Store)
+	}
+	return s.db
+}
+
+// etag returns a strong entity tag of the response body
+func etag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatch returns true if the If-None-Match header contains the entity tag
+func etagMatch(header string, tag string) bool {
+	if header == "" {
+		return false
+	}
+	for _, item := range strings.Split(header, ",") {
+		item = strings.TrimPrefix(strings.TrimSpace(item), "W/")
+		if item == tag || item == "*" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/figment-networks/near-indexer/store"
+)
+
+func TestResponseCacheWrite(t *testing.T) {
+	now := time.Now()
+	entry := &cacheEntry{
+		body:        []byte(`{}`),
+		contentType: "application/json",
+		etag:        `"tag"`,
+		expires:     now.Add(30 * time.Second),
+	}
+
+	examples := []struct {
+		private      bool
+		ifNoneMatch  string
+		status       int
+		cacheControl string
+	}{
+		{private: false, status: http.StatusOK, cacheControl: "public, max-age=30"},
+		{private: true, status: http.StatusOK, cacheControl: "private, max-age=30"},
+		{private: true, ifNoneMatch: `"tag"`, status: http.StatusNotModified, cacheControl: "private, max-age=30"},
+	}
+
+	for _, ex := range examples {
+		rc := &responseCache{private: ex.private}
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/validators", nil)
+		if ex.ifNoneMatch != "" {
+			c.Request.Header.Set("If-None-Match", ex.ifNoneMatch)
+		}
+
+		rc.write(c, entry, now)
+
+		assert.Equal(t, ex.status, w.Code)
+		assert.Equal(t, ex.cacheControl, w.Header().Get("Cache-Control"))
+		assert.Equal(t, apiKeyHeader, w.Header().Get("Vary"))
+		assert.Equal(t, `"tag"`, w.Header().Get("ETag"))
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/block_stats?limit=10&api_key=secret&bucket=h", nil)
+
+	assert.Equal(t, "/block_stats?bucket=h&limit=10", cacheKey(c))
+}
+
+func TestResponseCacheStore(t *testing.T) {
+	primary := &store.Store{}
+	s := Server{db: &store.Store{}}
+
+	rc := newResponseCache(primary, map[string]time.Duration{"/validators": time.Minute}, func() bool { return true }, false)
+
+	var used *store.Store
+	handler := func(c *gin.Context) {
+		used = s.requestStore(c)
+		c.JSON(http.StatusOK, gin.H{})
+	}
+
+	router := gin.New()
+	router.Use(rc.handler())
+	router.GET("/validators", handler)
+	router.GET("/blocks", handler)
+
+	// Cached routes read from the primary database
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/validators", nil))
+	assert.True(t, used == primary)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/blocks", nil))
+	assert.True(t, used == s.db)
+}
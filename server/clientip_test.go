@@ -0,0 +1,43 @@
+package server
+
+import (
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientIP(t *testing.T) {
+	_, proxies, _ := net.ParseCIDR("10.0.0.0/8")
+	trusted := []*net.IPNet{proxies}
+
+	examples := []struct {
+		remote    string
+		forwarded string
+		realIP    string
+		trusted   []*net.IPNet
+		result    string
+	}{
+		{remote: "1.2.3.4:5000", result: "1.2.3.4"},
+		{remote: "1.2.3.4:5000", forwarded: "5.6.7.8", result: "1.2.3.4"},
+		{remote: "1.2.3.4:5000", forwarded: "5.6.7.8", trusted: trusted, result: "1.2.3.4"},
+		{remote: "10.0.0.1:5000", forwarded: "5.6.7.8", result: "10.0.0.1"},
+		{remote: "10.0.0.1:5000", forwarded: "5.6.7.8", trusted: trusted, result: "5.6.7.8"},
+		{remote: "10.0.0.1:5000", forwarded: "9.9.9.9, 5.6.7.8, 10.0.0.2", trusted: trusted, result: "5.6.7.8"},
+		{remote: "10.0.0.1:5000", forwarded: "invalid", trusted: trusted, result: "10.0.0.1"},
+		{remote: "10.0.0.1:5000", realIP: "5.6.7.8", trusted: trusted, result: "5.6.7.8"},
+	}
+
+	for _, ex := range examples {
+		req := &http.Request{RemoteAddr: ex.remote, Header: http.Header{}}
+		if ex.forwarded != "" {
+			req.Header.Set("X-Forwarded-For", ex.forwarded)
+		}
+		if ex.realIP != "" {
+			req.Header.Set("X-Real-Ip", ex.realIP)
+		}
+
+		assert.Equal(t, ex.result, clientIP(req, ex.trusted), ex)
+	}
+}
@@ -0,0 +1,275 @@
+package server
+
+import (
+	"github.com/figment-networks/near-indexer/model"
+	"github.com/figment-networks/near-indexer/model/mapper"
+	"github.com/figment-networks/near-indexer/rosetta"
+	"github.com/figment-networks/near-indexer/store"
+)
+
+var (
+	exportFormatParam = apiParam{Name: "format", Enum: []string{exportFormatCSV, exportFormatNDJSON}}
+)
+
+// apiOperations documents all API operations, keyed by method and path.
+// Routes missing from this list are still included into the OpenAPI document.
+var apiOperations = map[string]apiOperation{
+	"GET /": {
+		Summary: "Get list of all available endpoints",
+	},
+	"GET /openapi.json": {
+		Summary: "Get OpenAPI specification",
+	},
+	"GET /docs": {
+		Summary: "Browse API documentation",
+	},
+	"GET /health": {
+		Summary: "Get service health",
+	},
+	"GET /status": {
+		Summary: "Get service and network status",
+	},
+	"GET /height": {
+		Summary: "Get current block height",
+	},
+	"GET /block": {
+		Summary:  "Get current block details",
+		Response: model.Block{},
+	},
+	"GET /blocks": {
+		Summary:  "Get latest blocks",
+		Params:   []interface{}{store.BlocksSearch{}},
+		Response: paginated(model.Block{}),
+	},
+	"GET /blocks/:id": {
+		Summary:  "Get block details by height or hash",
+		Params:   []interface{}{blockParams{}},
+		Response: blockDetails{},
+	},
+	"GET /blocks/:id/transactions": {
+		Summary:  "Get transactions included in a block",
+		Response: []model.Transaction{},
+	},
+	"GET /block_times": {
+		Summary: "Get average block times",
+		Params:  []interface{}{blockTimesParams{}},
+	},
+	"GET /block_stats": {
+		Summary: "Get block stats for a time bucket",
+		Params:  []interface{}{blockStatsParams{}},
+	},
+	"GET /validator_stats": {
+		Summary: "Get validator stats for a time bucket",
+		Params:  []interface{}{statsParams{}},
+	},
+	"GET /gas": {
+		Summary: "Get current gas price and gas usage stats",
+		Params:  []interface{}{gasStatsParams{}},
+	},
+	"GET /epochs": {
+		Summary:  "Get list of epochs",
+		Response: []model.Epoch{},
+	},
+	"GET /epochs/:id": {
+		Summary:  "Get epoch details",
+		Response: model.Epoch{},
+	},
+	"GET /epochs/stats": {
+		Summary: "Get epochs stake and supply stats",
+		Params:  []interface{}{epochStatsParams{}},
+	},
+	"GET /validators": {
+		Summary:  "List all validators",
+		Response: []model.ValidatorAgg{},
+	},
+	"GET /validators/:id": {
+		Summary: "Get validator details",
+	},
+	"GET /validators/next": {
+		Summary:  "Get projected next epoch validators",
+		Response: model.ValidatorSet{},
+	},
+	"GET /validators/:id/epochs": {
+		Summary:  "Get validator epochs performance",
+		Params:   []interface{}{store.Pagination{}},
+		Response: paginated(model.ValidatorEpoch{}),
+	},
+	"GET /validators/:id/events": {
+		Summary:  "Get validator events",
+		Params:   []interface{}{store.Pagination{}},
+		Response: paginated(model.Event{}),
+	},
+	"GET /validators/:id/delegators": {
+		Summary:  "Get validator delegators",
+		Params:   []interface{}{store.ValidatorDelegatorsSearch{}},
+		Response: store.ValidatorDelegatorsResult{},
+	},
+	"GET /delegators": {
+		Summary:  "Get list of delegators",
+		Params:   []interface{}{store.DelegatorEpochsSearch{}},
+		Response: []mapper.DelegatorInfo{},
+	},
+	"GET /delegators/:id/rewards": {
+		Summary:  "Get delegator rewards",
+		Params:   []interface{}{delegatorRewardsParams{}},
+		Response: []model.RewardsSummary{},
+	},
+	"GET /delegators/:id/tax_report": {
+		Summary: "Export delegator rewards tax report as CSV",
+		Params:  []interface{}{taxReportParams{}},
+	},
+	"GET /transactions": {
+		Summary:  "List all recent transactions",
+		Params:   []interface{}{store.TransactionsSearch{}},
+		Response: paginated(model.Transaction{}),
+	},
+	"GET /transactions/:id": {
+		Summary:  "Get transaction details",
+		Response: model.Transaction{},
+	},
+	"GET /transaction_stats": {
+		Summary: "Get transaction stats for a time bucket",
+		Params:  []interface{}{transactionStatsParams{}},
+	},
+	"GET /accounts/:id": {
+		Summary:  "Get account details",
+		Response: model.Account{},
+	},
+	"GET /accounts/:id/activity": {
+		Summary:  "Get account activity feed",
+		Params:   []interface{}{store.ActivitySearch{}},
+		Response: paginated(model.Activity{}),
+	},
+	"GET /search": {
+		Summary:  "Search blocks, transactions, epochs, validators and accounts",
+		Params:   []interface{}{searchParams{}},
+		Response: searchResponse{},
+	},
+	"GET /delegations/:id": {
+		Summary:  "Get account delegations",
+		Params:   []interface{}{apiParam{Name: "block_id", Type: "integer", Unsigned: true}},
+		Response: []model.Delegation{},
+	},
+	"GET /events": {
+		Summary:  "Get list of events",
+		Params:   []interface{}{store.EventsSearch{}},
+		Response: paginated(model.Event{}),
+	},
+	"GET /events/:id": {
+		Summary:  "Get event details",
+		Response: model.Event{},
+	},
+	"GET /network/economics": {
+		Summary:  "Get network inflation and burnt tokens",
+		Params:   []interface{}{networkEconomicsParams{}},
+		Response: []model.EconomicsSummary{},
+	},
+	"GET /network/active_accounts": {
+		Summary:  "Get daily, weekly or monthly active accounts",
+		Params:   []interface{}{activeAccountsParams{}},
+		Response: []model.ActiveAccountsSummary{},
+	},
+	"GET /stream": {
+		Summary: "Stream new blocks, transactions and events over WebSocket",
+		Params:  []interface{}{streamFilter{}},
+	},
+	"GET /stream/sse": {
+		Summary: "Stream new blocks, transactions and events as server-sent events",
+		Params:  []interface{}{streamFilter{}},
+	},
+	"GET /webhooks": {
+		Summary:  "Get list of webhooks",
+		Response: []model.Webhook{},
+	},
+	"POST /webhooks": {
+		Summary:  "Register a new webhook",
+		Body:     webhookParams{},
+		Response: webhookWithSecret{},
+	},
+	"GET /webhooks/:id": {
+		Summary:  "Get webhook details",
+		Response: model.Webhook{},
+	},
+	"PUT /webhooks/:id": {
+		Summary:  "Update a webhook",
+		Body:     webhookParams{},
+		Response: model.Webhook{},
+	},
+	"DELETE /webhooks/:id": {
+		Summary:  "Delete a webhook",
+		Response: model.Webhook{},
+	},
+	"GET /webhooks/:id/deliveries": {
+		Summary:  "Get webhook deliveries log",
+		Params:   []interface{}{store.WebhookDeliveriesSearch{}},
+		Response: paginated(model.WebhookDelivery{}),
+	},
+	"GET /admin/usage": {
+		Summary:  "Get API keys daily usage (requires an admin API key)",
+		Params:   []interface{}{adminUsageParams{}},
+		Response: apiUsageReport{},
+	},
+	"GET /graphql": {
+		Summary: "Query indexed data with GraphQL",
+		Params: []interface{}{
+			apiParam{Name: "query", Required: true},
+			apiParam{Name: "operationName"},
+			apiParam{Name: "variables"},
+		},
+	},
+	"POST /graphql": {
+		Summary: "Query indexed data with GraphQL",
+	},
+	"GET /export/transactions": {
+		Summary: "Export transactions as CSV or NDJSON",
+		Params:  []interface{}{store.TransactionsSearch{}, exportFormatParam},
+	},
+	"GET /export/delegator_rewards": {
+		Summary: "Export delegator rewards as CSV or NDJSON",
+		Params:  []interface{}{store.DelegatorEpochsSearch{}, exportFormatParam},
+	},
+	"GET /export/validator_epochs": {
+		Summary: "Export validator epochs as CSV or NDJSON",
+		Params:  []interface{}{validatorEpochsExportParams{}, exportFormatParam},
+	},
+
+	// Rosetta Data API
+	"POST /network/list": {
+		Summary:  "Rosetta: get list of supported networks",
+		Response: rosetta.NetworkListResponse{},
+	},
+	"POST /network/status": {
+		Summary:  "Rosetta: get network status",
+		Body:     rosetta.NetworkRequest{},
+		Response: rosetta.NetworkStatusResponse{},
+	},
+	"POST /network/options": {
+		Summary:  "Rosetta: get supported API options",
+		Body:     rosetta.NetworkRequest{},
+		Response: rosetta.NetworkOptionsResponse{},
+	},
+	"POST /block": {
+		Summary:  "Rosetta: get block with all its transactions",
+		Body:     rosetta.BlockRequest{},
+		Response: rosetta.BlockResponse{},
+	},
+	"POST /block/transaction": {
+		Summary:  "Rosetta: get a transaction of a block",
+		Body:     rosetta.BlockTransactionRequest{},
+		Response: rosetta.BlockTransactionResponse{},
+	},
+	"POST /account/balance": {
+		Summary:  "Rosetta: get account balance",
+		Body:     rosetta.AccountBalanceRequest{},
+		Response: rosetta.AccountBalanceResponse{},
+	},
+	"POST /mempool": {
+		Summary:  "Rosetta: get list of mempool transactions",
+		Body:     rosetta.NetworkRequest{},
+		Response: rosetta.MempoolResponse{},
+	},
+	"POST /mempool/transaction": {
+		Summary: "Rosetta: get a mempool transaction",
+		Body:    rosetta.MempoolTransactionRequest{},
+	},
+}
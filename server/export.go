@@ -0,0 +1,274 @@
+package server
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/figment-networks/near-indexer/model"
+	"github.com/figment-networks/near-indexer/store"
+)
+
+const (
+	exportFormatCSV    = "csv"
+	exportFormatNDJSON = "ndjson"
+
+	// exportFlushEvery defines how often the exported records are flushed to the client
+	exportFlushEvery = 100
+)
+
+var (
+	transactionsExportHeader = []string{
+		"time", "height", "hash", "block_hash", "sender", "receiver", "amount",
+		"gas_burnt", "tokens_burnt", "fee", "actions_count", "success",
+	}
+
+	delegatorEpochsExportHeader = []string{
+		"account_id", "validator_id", "epoch", "distributed_at_epoch", "distributed_at_height",
+		"distributed_at_time", "staked_balance", "unstaked_balance", "reward",
+	}
+
+	validatorEpochsExportHeader = []string{
+		"account_id", "epoch", "last_height", "last_time", "expected_blocks", "produced_blocks",
+		"expected_chunks", "produced_chunks", "efficiency", "uptime", "staking_balance", "reward_fee",
+	}
+)
+
+type validatorEpochsExportParams struct {
+	ValidatorID string `form:"validator_id" binding:"required"`
+}
+
+// exportWriter streams records to the client as CSV or NDJSON
+type exportWriter struct {
+	c      *gin.Context
+	format string
+	csv    *csv.Writer
+	json   *json.Encoder
+	count  int
+}
+
+// exportFormat returns the format requested with the format param or Accept header
+func exportFormat(c *gin.Context) (string, error) {
+	format := c.Query("format")
+	if format == "" {
+		accept := c.GetHeader("Accept")
+		switch {
+		case strings.Contains(accept, "text/csv"):
+			format = exportFormatCSV
+		default:
+			format = exportFormatNDJSON
+		}
+	}
+
+	switch format {
+	case exportFormatCSV, exportFormatNDJSON:
+		return format, nil
+	default:
+		return "", errors.New("invalid export format: " + format)
+	}
+}
+
+// newExportWriter prepares the response for streaming the export file
+func newExportWriter(c *gin.Context, format string, name string, header []string) (*exportWriter, error) {
+	w := &exportWriter{c: c, format: format}
+
+	filename := fmt.Sprintf("%s.%s", name, format)
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+
+	switch format {
+	case exportFormatCSV:
+		c.Header("Content-Type", "text/csv")
+		w.csv = csv.NewWriter(c.Writer)
+		if err := w.csv.Write(header); err != nil {
+			return nil, err
+		}
+	default:
+		c.Header("Content-Type", "application/x-ndjson")
+		w.json = json.NewEncoder(c.Writer)
+	}
+
+	c.Status(200)
+	return w, nil
+}
+
+// Write writes a single record using its JSON form or CSV row
+func (w *exportWriter) Write(record interface{}, row []string) error {
+	var err error
+	if w.csv != nil {
+		err = w.csv.Write(row)
+	} else {
+		err = w.json.Encode(record)
+	}
+	if err != nil {
+		return err
+	}
+
+	w.count++
+	if w.count%exportFlushEvery == 0 {
+		return w.Flush()
+	}
+	return nil
+}
+
+// Flush sends all buffered data to the client
+func (w *exportWriter) Flush() error {
+	if w.csv != nil {
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil {
+			return err
+		}
+	}
+	w.c.Writer.Flush()
+	return nil
+}
+
+// streamExport runs the export function and reports errors once the stream has started
+func (s Server) streamExport(w *exportWriter, fn func() error) {
+	err := fn()
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		s.log.WithError(err).WithField("path", w.c.Request.URL.Path).Error("export failed")
+	}
+}
+
+// GetTransactionsExport streams all transactions matching the search
+func (s Server) GetTransactionsExport(c *gin.Context) {
+	search := store.TransactionsSearch{}
+	if err := c.BindQuery(&search); err != nil {
+		badRequest(c, err)
+		return
+	}
+	if err := search.Validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	format, err := exportFormat(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	w, err := newExportWriter(c, format, "transactions", transactionsExportHeader)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	s.streamExport(w, func() error {
+		return s.db.Transactions.Export(search, func(tx *model.Transaction) error {
+			return w.Write(tx, []string{
+				formatExportTime(tx.Time),
+				tx.Height.String(),
+				tx.Hash,
+				tx.BlockHash,
+				tx.Sender,
+				tx.Receiver,
+				tx.Amount.String(),
+				tx.GasBurnt,
+				tx.TokensBurnt.String(),
+				tx.Fee.String(),
+				strconv.Itoa(tx.ActionsCount),
+				strconv.FormatBool(tx.Success),
+			})
+		})
+	})
+}
+
+// GetDelegatorRewardsExport streams all delegator epochs matching the search
+func (s Server) GetDelegatorRewardsExport(c *gin.Context) {
+	search := store.DelegatorEpochsSearch{}
+	if err := c.BindQuery(&search); err != nil {
+		badRequest(c, err)
+		return
+	}
+	if err := search.Validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	format, err := exportFormat(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	w, err := newExportWriter(c, format, "delegator_rewards", delegatorEpochsExportHeader)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	s.streamExport(w, func() error {
+		return s.db.Delegators.ExportDelegatorEpochs(search, func(de *model.DelegatorEpoch) error {
+			return w.Write(de, []string{
+				de.AccountID,
+				de.ValidatorID,
+				de.Epoch,
+				de.DistributedAtEpoch,
+				de.DistributedAtHeight.String(),
+				formatExportTime(de.DistributedAtTime),
+				de.StakedBalance.String(),
+				de.UnstakedBalance.String(),
+				de.Reward.String(),
+			})
+		})
+	})
+}
+
+// GetValidatorEpochsExport streams all epochs of a validator
+func (s Server) GetValidatorEpochsExport(c *gin.Context) {
+	params := validatorEpochsExportParams{}
+	if err := c.BindQuery(&params); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	format, err := exportFormat(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	w, err := newExportWriter(c, format, "validator_epochs", validatorEpochsExportHeader)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	s.streamExport(w, func() error {
+		return s.db.ValidatorAggs.ExportValidatorEpochs(params.ValidatorID, func(ve *model.ValidatorEpoch) error {
+			rewardFee := ""
+			if ve.RewardFee != nil {
+				rewardFee = strconv.Itoa(*ve.RewardFee)
+			}
+
+			return w.Write(ve, []string{
+				ve.AccountID,
+				ve.Epoch,
+				ve.LastHeight.String(),
+				formatExportTime(ve.LastTime),
+				strconv.Itoa(ve.ExpectedBlocks),
+				strconv.Itoa(ve.ProducedBlocks),
+				strconv.Itoa(ve.ExpectedChunks),
+				strconv.Itoa(ve.ProducedChunks),
+				strconv.FormatFloat(ve.Efficiency, 'f', -1, 64),
+				strconv.FormatFloat(ve.Uptime, 'f', -1, 64),
+				ve.StakingBalance.String(),
+				rewardFee,
+			})
+		})
+	})
+}
+
+func formatExportTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
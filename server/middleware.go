@@ -1,6 +1,7 @@
 package server
 
 import (
+	"net"
 	"net/http"
 	"time"
 
@@ -21,7 +22,7 @@ func RollbarMiddleware() gin.HandlerFunc {
 	}
 }
 
-func requestLogger(logger *logrus.Logger) gin.HandlerFunc {
+func requestLogger(logger *logrus.Logger, trustedProxies []*net.IPNet) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
 		c.Next()
@@ -32,7 +33,7 @@ func requestLogger(logger *logrus.Logger) gin.HandlerFunc {
 
 		field := logger.
 			WithField("method", c.Request.Method).
-			WithField("client", c.ClientIP()).
+			WithField("client", clientIP(c.Request, trustedProxies)).
 			WithField("status", status).
 			WithField("duration", duration.Milliseconds()).
 			WithField("path", c.Request.URL.Path)
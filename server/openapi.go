@@ -0,0 +1,558 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+
+	"github.com/figment-networks/near-indexer/config"
+	"github.com/figment-networks/near-indexer/model/types"
+)
+
+const (
+	openAPIVersion = "3.0.3"
+	swaggerUIURL   = "https://unpkg.com/swagger-ui-dist@3"
+)
+
+var (
+	rePathParam = regexp.MustCompile(`:(\w+)`)
+
+	typeTime       = reflect.TypeOf(time.Time{})
+	typeAmount     = reflect.TypeOf(types.Amount{})
+	typeHeight     = reflect.TypeOf(types.Height(0))
+	typeMap        = reflect.TypeOf(types.Map{})
+	typeRawMessage = reflect.TypeOf(json.RawMessage{})
+	typeStrings    = reflect.TypeOf(pq.StringArray{})
+)
+
+// apiOperation describes an API operation
+type apiOperation struct {
+	Summary  string
+	Params   []interface{} // param structs bound from the query string, or apiParam values
+	Body     interface{}
+	Response interface{}
+
+	params []apiParam
+}
+
+// apiParam describes a single query or path parameter
+type apiParam struct {
+	Name     string
+	In       string
+	Type     string
+	Format   string
+	Enum     []string
+	Required bool
+	Unsigned bool
+}
+
+// paginatedResponse describes a paginated list of records
+type paginatedResponse struct {
+	Records interface{}
+}
+
+func paginated(records interface{}) paginatedResponse {
+	return paginatedResponse{Records: records}
+}
+
+// apiDocs holds the OpenAPI document built from the registered routes
+type apiDocs struct {
+	sync.Mutex
+
+	operations map[string]*apiOperation
+	routes     gin.RoutesInfo
+	document   []byte
+}
+
+func newAPIDocs(operations map[string]apiOperation) *apiDocs {
+	docs := &apiDocs{operations: map[string]*apiOperation{}}
+
+	for key, op := range operations {
+		op := op
+		for _, src := range op.Params {
+			op.params = append(op.params, paramsOf(src)...)
+		}
+		docs.operations[key] = &op
+	}
+
+	return docs
+}
+
+// setRoutes assigns the list of routes to document
+func (d *apiDocs) setRoutes(routes gin.RoutesInfo) {
+	d.Lock()
+	defer d.Unlock()
+
+	d.routes = routes
+	d.document = nil
+}
+
+// find returns a documented operation for the request
+func (d *apiDocs) find(c *gin.Context) *apiOperation {
+	// Some static paths are dispatched by wildcard route handlers
+	if op, ok := d.operations[c.Request.Method+" "+c.Request.URL.Path]; ok {
+		return op
+	}
+	return d.operations[c.Request.Method+" "+c.FullPath()]
+}
+
+// paths returns all documented method and path pairs
+func (d *apiDocs) paths() []string {
+	d.Lock()
+	defer d.Unlock()
+
+	keys := map[string]bool{}
+	for _, r := range d.routes {
+		keys[r.Method+" "+r.Path] = true
+	}
+	// Include static paths that are handled by wildcard routes
+	for key := range d.operations {
+		if keys[key] {
+			continue
+		}
+		parts := strings.SplitN(key, " ", 2)
+		for _, r := range d.routes {
+			if r.Method == parts[0] && matchesRoute(r.Path, parts[1]) {
+				keys[key] = true
+			}
+		}
+	}
+
+	result := make([]string, 0, len(keys))
+	for key := range keys {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// Document returns the OpenAPI document in JSON format
+func (d *apiDocs) Document() ([]byte, error) {
+	paths := d.paths()
+
+	d.Lock()
+	defer d.Unlock()
+
+	if d.document != nil {
+		return d.document, nil
+	}
+
+	b := &schemaBuilder{schemas: map[string]interface{}{}}
+	specPaths := map[string]map[string]interface{}{}
+
+	for _, key := range paths {
+		parts := strings.SplitN(key, " ", 2)
+		method, path := parts[0], parts[1]
+
+		specPath := rePathParam.ReplaceAllString(path, "{$1}")
+		if specPaths[specPath] == nil {
+			specPaths[specPath] = map[string]interface{}{}
+		}
+
+		op := d.operations[key]
+		if op == nil {
+			op = &apiOperation{}
+		}
+		specPaths[specPath][strings.ToLower(method)] = b.operation(path, op)
+	}
+
+	doc := map[string]interface{}{
+		"openapi": openAPIVersion,
+		"info": map[string]interface{}{
+			"title":   "NEAR Indexer API",
+			"version": config.AppVersion,
+		},
+		"paths": specPaths,
+		"components": map[string]interface{}{
+			"schemas": b.schemas,
+		},
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	d.document = data
+
+	return data, nil
+}
+
+// validateParams renders a bad request response when query params do not match the spec
+func validateParams(docs *apiDocs) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		op := docs.find(c)
+		if op == nil {
+			return
+		}
+
+		query := c.Request.URL.Query()
+		for _, p := range op.params {
+			if err := p.validate(query); err != nil {
+				badRequest(c, err)
+				return
+			}
+		}
+	}
+}
+
+// GetOpenAPI renders the OpenAPI document
+func (s Server) GetOpenAPI(c *gin.Context) {
+	data, err := s.docs.Document()
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	jsonOk(c, data)
+}
+
+// GetDocs renders the Swagger UI for the OpenAPI document
+func (s Server) GetDocs(c *gin.Context) {
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	c.String(http.StatusOK, swaggerUI, swaggerUIURL, swaggerUIURL)
+}
+
+func (p apiParam) validate(query url.Values) error {
+	if p.In != "query" {
+		return nil
+	}
+
+	value := query.Get(p.Name)
+	if value == "" {
+		if p.Required {
+			return fmt.Errorf("%s param is required", p.Name)
+		}
+		return nil
+	}
+
+	switch p.Type {
+	case "integer":
+		if p.Unsigned {
+			if _, err := strconv.ParseUint(value, 10, 64); err != nil {
+				return fmt.Errorf("%s param must be a non-negative integer", p.Name)
+			}
+		} else if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return fmt.Errorf("%s param must be an integer", p.Name)
+		}
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("%s param must be a number", p.Name)
+		}
+	case "boolean":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("%s param must be a boolean", p.Name)
+		}
+	}
+
+	switch p.Format {
+	case "date":
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			return fmt.Errorf("%s param must be a date in YYYY-MM-DD format", p.Name)
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			return fmt.Errorf("%s param must be a time in RFC3339 format", p.Name)
+		}
+	}
+
+	if len(p.Enum) > 0 {
+		for _, item := range p.Enum {
+			if item == value {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s param must be one of: %s", p.Name, strings.Join(p.Enum, ", "))
+	}
+
+	return nil
+}
+
+func (p apiParam) spec() map[string]interface{} {
+	schema := map[string]interface{}{"type": p.Type}
+	if p.Format != "" {
+		schema["format"] = p.Format
+	}
+	if p.Unsigned {
+		schema["minimum"] = 0
+	}
+	if len(p.Enum) > 0 {
+		schema["enum"] = p.Enum
+	}
+
+	return map[string]interface{}{
+		"name":     p.Name,
+		"in":       p.In,
+		"required": p.Required,
+		"schema":   schema,
+	}
+}
+
+// paramsOf returns params of a struct bound with form tags
+func paramsOf(src interface{}) []apiParam {
+	if p, ok := src.(apiParam); ok {
+		if p.In == "" {
+			p.In = "query"
+		}
+		if p.Type == "" {
+			p.Type = "string"
+		}
+		return []apiParam{p}
+	}
+
+	result := []apiParam{}
+	t := reflect.TypeOf(src)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			result = append(result, paramsOf(reflect.Zero(field.Type).Interface())...)
+			continue
+		}
+
+		name := field.Tag.Get("form")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		p := apiParam{
+			Name:     name,
+			In:       "query",
+			Type:     "string",
+			Required: strings.Contains(field.Tag.Get("binding"), "required"),
+		}
+		if enum := field.Tag.Get("enum"); enum != "" {
+			p.Enum = strings.Split(enum, ",")
+		}
+
+		switch field.Type.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			p.Type = "integer"
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			p.Type = "integer"
+			p.Unsigned = true
+		case reflect.Float32, reflect.Float64:
+			p.Type = "number"
+		case reflect.Bool:
+			p.Type = "boolean"
+		}
+		if field.Type == typeTime {
+			p.Format = "date-time"
+			if field.Tag.Get("time_format") == "2006-01-02" {
+				p.Format = "date"
+			}
+		}
+
+		result = append(result, p)
+	}
+
+	return result
+}
+
+// matchesRoute returns true if the path is matched by the route pattern
+func matchesRoute(route string, path string) bool {
+	routeParts := strings.Split(route, "/")
+	pathParts := strings.Split(path, "/")
+	if len(routeParts) != len(pathParts) {
+		return false
+	}
+	for i := range routeParts {
+		if strings.HasPrefix(routeParts[i], ":") {
+			continue
+		}
+		if routeParts[i] != pathParts[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// schemaBuilder builds JSON schemas of Go types
+type schemaBuilder struct {
+	schemas map[string]interface{}
+}
+
+func (b *schemaBuilder) operation(path string, op *apiOperation) map[string]interface{} {
+	params := []interface{}{}
+	for _, match := range rePathParam.FindAllStringSubmatch(path, -1) {
+		params = append(params, apiParam{Name: match[1], In: "path", Type: "string", Required: true}.spec())
+	}
+	for _, p := range op.params {
+		params = append(params, p.spec())
+	}
+
+	response := map[string]interface{}{"description": "Successful response"}
+	if op.Response != nil {
+		response["content"] = map[string]interface{}{
+			"application/json": map[string]interface{}{
+				"schema": b.responseSchema(op.Response),
+			},
+		}
+	}
+
+	result := map[string]interface{}{
+		"summary":    op.Summary,
+		"parameters": params,
+		"responses": map[string]interface{}{
+			"200": response,
+			"400": map[string]interface{}{"description": "Invalid request params"},
+		},
+	}
+
+	if op.Body != nil {
+		result["requestBody"] = map[string]interface{}{
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{
+					"schema": b.schema(reflect.TypeOf(op.Body)),
+				},
+			},
+		}
+	}
+
+	return result
+}
+
+func (b *schemaBuilder) responseSchema(response interface{}) map[string]interface{} {
+	if p, ok := response.(paginatedResponse); ok {
+		return map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"page":        map[string]interface{}{"type": "integer"},
+				"pages":       map[string]interface{}{"type": "integer"},
+				"limit":       map[string]interface{}{"type": "integer"},
+				"count":       map[string]interface{}{"type": "integer"},
+				"next_cursor": map[string]interface{}{"type": "string"},
+				"prev_cursor": map[string]interface{}{"type": "string"},
+				"records": map[string]interface{}{
+					"type":  "array",
+					"items": b.schema(reflect.TypeOf(p.Records)),
+				},
+			},
+		}
+	}
+	return b.schema(reflect.TypeOf(response))
+}
+
+func (b *schemaBuilder) schema(t reflect.Type) map[string]interface{} {
+	switch t {
+	case typeTime:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case typeAmount:
+		return map[string]interface{}{"type": "string", "description": "Amount in yoctoNEAR"}
+	case typeHeight:
+		return map[string]interface{}{"type": "integer"}
+	case typeMap:
+		return map[string]interface{}{"type": "object"}
+	case typeRawMessage:
+		return map[string]interface{}{}
+	case typeStrings:
+		return map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := b.schema(t.Elem())
+		if _, ok := schema["$ref"]; ok {
+			return map[string]interface{}{"allOf": []interface{}{schema}, "nullable": true}
+		}
+		schema["nullable"] = true
+		return schema
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": b.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": b.schema(t.Elem())}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Struct:
+		return b.structSchema(t)
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// structSchema registers a named struct schema component and returns its reference
+func (b *schemaBuilder) structSchema(t reflect.Type) map[string]interface{} {
+	if t.Name() == "" {
+		return b.objectSchema(t)
+	}
+
+	name := t.String()
+	if _, ok := b.schemas[name]; !ok {
+		// Reserve the name first to support recursive types
+		b.schemas[name] = map[string]interface{}{}
+		b.schemas[name] = b.objectSchema(t)
+	}
+
+	return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+}
+
+func (b *schemaBuilder) objectSchema(t reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	b.addProperties(t, properties)
+
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+}
+
+func (b *schemaBuilder) addProperties(t reflect.Type, properties map[string]interface{}) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		name := strings.Split(tag, ",")[0]
+
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				b.addProperties(ft, properties)
+				continue
+			}
+		}
+		if field.PkgPath != "" || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		properties[name] = b.schema(field.Type)
+	}
+}
+
+const swaggerUI = `<!DOCTYPE html>
+<html>
+<head>
+  <title>NEAR Indexer API</title>
+  <link rel="stylesheet" href="%s/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="%s/swagger-ui-bundle.js"></script>
+  <script>
+    SwaggerUIBundle({ url: "/openapi.json", dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>
+`
@@ -2,24 +2,90 @@ package server
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/figment-networks/near-indexer/model"
+	"github.com/figment-networks/near-indexer/store"
+)
+
+var (
+	// maxStatsRange defines the max time range allowed for each stats bucket
+	maxStatsRange = map[string]time.Duration{
+		store.BucketMinute: time.Hour * 24,
+		store.BucketHour:   time.Hour * 24 * 31,
+		store.BucketDay:    time.Hour * 24 * 366 * 2,
+		store.BucketWeek:   time.Hour * 24 * 366 * 5,
+		store.BucketMonth:  time.Hour * 24 * 366 * 10,
+	}
 )
 
 type statsParams struct {
-	Bucket string `form:"bucket"`
+	Bucket string `form:"bucket" enum:"h,d"`
+	Limit  uint   `form:"limit"`
+}
+
+type transactionStatsParams struct {
+	Bucket string `form:"bucket" enum:"h,d,w,mon"`
 	Limit  uint   `form:"limit"`
 }
 
+type gasStatsParams struct {
+	Bucket string `form:"bucket" enum:"min,h,d,w,mon"`
+	Limit  uint   `form:"limit"`
+}
+
+type blockStatsParams struct {
+	gasStatsParams
+	From time.Time `form:"from"`
+	To   time.Time `form:"to"`
+}
+
+type blockParams struct {
+	Include string `form:"include"`
+}
+
 type blockTimesParams struct {
 	Limit int64 `form:"limit"`
 }
 
+type epochStatsParams struct {
+	Limit uint `form:"limit"`
+}
+
 type accountsIndexParams struct {
 	Height int64 `form:"height"`
 }
 
+func (p *blockParams) Validate() error {
+	for _, name := range p.includes() {
+		switch name {
+		case "transactions", "chunks", "events":
+		default:
+			return errors.New("invalid include: " + name)
+		}
+	}
+	return nil
+}
+
+// Includes returns true if the given relation is requested
+func (p *blockParams) Includes(name string) bool {
+	for _, item := range p.includes() {
+		if item == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *blockParams) includes() []string {
+	if p.Include == "" {
+		return nil
+	}
+	return strings.Split(p.Include, ",")
+}
+
 func (p *blockTimesParams) setDefaults() {
 	if p.Limit <= 0 {
 		p.Limit = 100
@@ -29,28 +95,107 @@ func (p *blockTimesParams) setDefaults() {
 	}
 }
 
+func (p *epochStatsParams) setDefaults() {
+	if p.Limit == 0 {
+		p.Limit = 30
+	}
+	if p.Limit > 500 {
+		p.Limit = 500
+	}
+}
+
 func (p *statsParams) Validate() error {
-	if p.Bucket == "" {
-		p.Bucket = "h"
+	return validateStatsBucket(&p.Bucket, &p.Limit, store.BucketHour, store.BucketDay)
+}
+
+func (p *transactionStatsParams) Validate() error {
+	return validateStatsBucket(&p.Bucket, &p.Limit, store.BucketHour, store.BucketDay, store.BucketWeek, store.BucketMonth)
+}
+
+func (p *gasStatsParams) Validate() error {
+	return validateStatsBucket(&p.Bucket, &p.Limit, store.BucketMinute, store.BucketHour, store.BucketDay, store.BucketWeek, store.BucketMonth)
+}
+
+// validateStatsBucket checks that the bucket is one of the populated buckets,
+// and sets the default bucket and limit values
+func validateStatsBucket(bucket *string, limit *uint, buckets ...string) error {
+	if *bucket == "" {
+		*bucket = store.BucketHour
 	}
 
-	switch p.Bucket {
-	case "d":
-		if p.Limit == 0 {
-			p.Limit = 30
+	supported := false
+	for _, b := range buckets {
+		if b == *bucket {
+			supported = true
+			break
 		}
-		if p.Limit > 90 {
-			return errors.New("maximum daily limit is 90")
+	}
+	if !supported {
+		return errors.New("invalid time bucket: " + *bucket)
+	}
+
+	switch *bucket {
+	case store.BucketMinute:
+		if *limit == 0 {
+			*limit = 60
+		}
+		if *limit > 1440 {
+			return errors.New("max minute limit is 1440")
 		}
-	case "h":
-		if p.Limit == 0 {
-			p.Limit = 24
+	case store.BucketHour:
+		if *limit == 0 {
+			*limit = 24
 		}
-		if p.Limit > 48 {
+		if *limit > 48 {
 			return errors.New("max hourly limit is 48")
 		}
-	default:
-		return errors.New("invalid time bucket: " + p.Bucket)
+	case store.BucketDay:
+		if *limit == 0 {
+			*limit = 30
+		}
+		if *limit > 90 {
+			return errors.New("maximum daily limit is 90")
+		}
+	case store.BucketWeek:
+		if *limit == 0 {
+			*limit = 12
+		}
+		if *limit > 104 {
+			return errors.New("max weekly limit is 104")
+		}
+	case store.BucketMonth:
+		if *limit == 0 {
+			*limit = 12
+		}
+		if *limit > 36 {
+			return errors.New("max monthly limit is 36")
+		}
+	}
+
+	return nil
+}
+
+// HasRange returns true if an explicit time range is requested
+func (p *blockStatsParams) HasRange() bool {
+	return !p.From.IsZero() || !p.To.IsZero()
+}
+
+func (p *blockStatsParams) Validate() error {
+	if err := p.gasStatsParams.Validate(); err != nil {
+		return err
+	}
+	if !p.HasRange() {
+		return nil
+	}
+
+	if p.To.IsZero() {
+		p.To = time.Now()
+	}
+	if p.From.IsZero() || !p.From.Before(p.To) {
+		return errors.New("time range is invalid")
+	}
+	if maxRange := maxStatsRange[p.Bucket]; p.To.Sub(p.From) > maxRange {
+		return fmt.Errorf("max time range for %s bucket is %v", p.Bucket, maxRange)
 	}
 
 	return nil
@@ -59,7 +204,49 @@ func (p *statsParams) Validate() error {
 type rewardsParams struct {
 	From     time.Time `form:"from" time_format:"2006-01-02"`
 	To       time.Time `form:"to" time_format:"2006-01-02"`
-	Interval string    `form:"interval" binding:"required"`
+	Interval string    `form:"interval" binding:"required" enum:"daily,weekly,monthly,yearly"`
+}
+
+type networkEconomicsParams struct {
+	rewardsParams
+}
+
+type activeAccountsParams struct {
+	rewardsParams
+}
+
+func (p *activeAccountsParams) Validate() error {
+	if err := p.rewardsParams.Validate(); err != nil {
+		return err
+	}
+	if interval, _ := model.GetTypeForTimeInterval(p.Interval); interval == model.TimeIntervalYearly {
+		return errors.New("yearly interval is not supported")
+	}
+	return nil
+}
+
+type taxReportParams struct {
+	ValidatorID string `form:"validator_id"`
+	StartDate   string `form:"start_date"`
+	EndDate     string `form:"end_date"`
+	Layout      string `form:"layout" enum:"generic,koinly,cointracking"`
+	Currency    string `form:"currency"`
+}
+
+func (p *taxReportParams) Validate() error {
+	if p.Layout == "" {
+		p.Layout = taxReportLayoutGeneric
+	}
+	if _, ok := taxReportLayouts[p.Layout]; !ok {
+		return errors.New("invalid layout: " + p.Layout)
+	}
+
+	if p.Currency == "" {
+		p.Currency = "USD"
+	}
+	p.Currency = strings.ToUpper(p.Currency)
+
+	return nil
 }
 
 type delegatorRewardsParams struct {
@@ -73,3 +260,17 @@ func (p *rewardsParams) Validate() error {
 	}
 	return nil
 }
+
+type adminUsageParams struct {
+	Days  uint  `form:"days"`
+	KeyID int64 `form:"key_id"`
+}
+
+func (p *adminUsageParams) setDefaults() {
+	if p.Days == 0 {
+		p.Days = 7
+	}
+	if p.Days > 90 {
+		p.Days = 90
+	}
+}
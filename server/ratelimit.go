@@ -0,0 +1,84 @@
+package server
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	limiterIdleTTL         = 10 * time.Minute
+	limiterCleanupInterval = time.Minute
+)
+
+// rateLimiters holds token buckets for API keys and client IPs
+type rateLimiters struct {
+	mu          sync.Mutex
+	items       map[string]*limiterEntry
+	lastCleanup time.Time
+}
+
+type limiterEntry struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+func newRateLimiters() *rateLimiters {
+	return &rateLimiters{
+		items:       map[string]*limiterEntry{},
+		lastCleanup: time.Now(),
+	}
+}
+
+// allow takes a token from the bucket identified by the key. When the bucket is
+// empty it returns false along with the time until the next token is available.
+// Zero limit disables the rate limiting.
+func (r *rateLimiters) allow(key string, limit float64, burst int, now time.Time) (bool, time.Duration) {
+	if limit <= 0 {
+		return true, 0
+	}
+	if burst < 1 {
+		burst = 1
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if now.Sub(r.lastCleanup) >= limiterCleanupInterval {
+		r.cleanup(now)
+	}
+
+	entry, ok := r.items[key]
+	if !ok {
+		entry = &limiterEntry{limiter: rate.NewLimiter(rate.Limit(limit), burst)}
+		r.items[key] = entry
+	}
+	entry.lastSeen = now
+
+	// Limits of API keys could be changed while the bucket is in use
+	if entry.limiter.Limit() != rate.Limit(limit) {
+		entry.limiter.SetLimitAt(now, rate.Limit(limit))
+	}
+	if entry.limiter.Burst() != burst {
+		entry.limiter.SetBurstAt(now, burst)
+	}
+
+	if entry.limiter.AllowN(now, 1) {
+		return true, 0
+	}
+
+	wait := time.Duration(math.Ceil(float64(time.Second) / limit))
+	return false, wait
+}
+
+// cleanup removes buckets that have not been used recently
+func (r *rateLimiters) cleanup(now time.Time) {
+	for key, entry := range r.items {
+		if now.Sub(entry.lastSeen) >= limiterIdleTTL {
+			delete(r.items, key)
+		}
+	}
+	r.lastCleanup = now
+}
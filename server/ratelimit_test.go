@@ -0,0 +1,135 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitersAllow(t *testing.T) {
+	type request struct {
+		after   time.Duration
+		limit   float64
+		burst   int
+		allowed bool
+		wait    time.Duration
+	}
+
+	examples := []struct {
+		name     string
+		requests []request
+	}{
+		{
+			name: "disabled",
+			requests: []request{
+				{limit: 0, burst: 1, allowed: true},
+				{limit: 0, burst: 1, allowed: true},
+				{limit: -1, burst: 1, allowed: true},
+			},
+		},
+		{
+			name: "burst",
+			requests: []request{
+				{limit: 1, burst: 2, allowed: true},
+				{limit: 1, burst: 2, allowed: true},
+				{limit: 1, burst: 2, allowed: false, wait: time.Second},
+				{after: time.Second, limit: 1, burst: 2, allowed: true},
+				{limit: 1, burst: 2, allowed: false, wait: time.Second},
+			},
+		},
+		{
+			name: "zero burst",
+			requests: []request{
+				{limit: 1, burst: 0, allowed: true},
+				{limit: 1, burst: 0, allowed: false, wait: time.Second},
+			},
+		},
+		{
+			name: "wait",
+			requests: []request{
+				{limit: 10, burst: 1, allowed: true},
+				{limit: 10, burst: 1, allowed: false, wait: 100 * time.Millisecond},
+				{limit: 3, burst: 1, allowed: false, wait: 333333334 * time.Nanosecond},
+			},
+		},
+		{
+			name: "limit increase",
+			requests: []request{
+				{limit: 1, burst: 1, allowed: true},
+				{limit: 1, burst: 1, allowed: false, wait: time.Second},
+				{after: 100 * time.Millisecond, limit: 1, burst: 1, allowed: false, wait: time.Second},
+				// Tokens accumulated at the previous limit are kept
+				{after: 100 * time.Millisecond, limit: 10, burst: 1, allowed: false, wait: 100 * time.Millisecond},
+				{after: 100 * time.Millisecond, limit: 10, burst: 1, allowed: true},
+			},
+		},
+		{
+			name: "limit decrease",
+			requests: []request{
+				{limit: 10, burst: 1, allowed: true},
+				{limit: 1, burst: 1, allowed: false, wait: time.Second},
+				{after: 100 * time.Millisecond, limit: 1, burst: 1, allowed: false, wait: time.Second},
+				{after: time.Second, limit: 1, burst: 1, allowed: true},
+			},
+		},
+		{
+			name: "burst increase",
+			requests: []request{
+				{limit: 1, burst: 1, allowed: true},
+				// Bucket only fills up to the new burst from now on
+				{after: 3 * time.Second, limit: 1, burst: 3, allowed: true},
+				{limit: 1, burst: 3, allowed: false, wait: time.Second},
+				{after: 3 * time.Second, limit: 1, burst: 3, allowed: true},
+				{limit: 1, burst: 3, allowed: true},
+				{limit: 1, burst: 3, allowed: true},
+				{limit: 1, burst: 3, allowed: false, wait: time.Second},
+			},
+		},
+		{
+			name: "burst decrease",
+			requests: []request{
+				{limit: 1, burst: 3, allowed: true},
+				{limit: 1, burst: 1, allowed: true},
+				{limit: 1, burst: 1, allowed: false, wait: time.Second},
+			},
+		},
+	}
+
+	for _, ex := range examples {
+		t.Run(ex.name, func(t *testing.T) {
+			limiters := newRateLimiters()
+			now := limiters.lastCleanup
+
+			for i, req := range ex.requests {
+				now = now.Add(req.after)
+
+				allowed, wait := limiters.allow("key", req.limit, req.burst, now)
+				assert.Equal(t, req.allowed, allowed, "request %d", i)
+				assert.Equal(t, req.wait, wait, "request %d", i)
+			}
+		})
+	}
+}
+
+func TestRateLimitersKeys(t *testing.T) {
+	limiters := newRateLimiters()
+	now := limiters.lastCleanup
+
+	allowed, _ := limiters.allow("ip:1", 1, 1, now)
+	assert.True(t, allowed)
+	allowed, _ = limiters.allow("ip:1", 1, 1, now)
+	assert.False(t, allowed)
+
+	// Buckets are separate for every key
+	allowed, _ = limiters.allow("ip:2", 1, 1, now)
+	assert.True(t, allowed)
+	assert.Len(t, limiters.items, 2)
+
+	// Idle buckets are removed
+	now = now.Add(limiterIdleTTL)
+	allowed, _ = limiters.allow("ip:3", 1, 1, now)
+	assert.True(t, allowed)
+	assert.Len(t, limiters.items, 1)
+	assert.Contains(t, limiters.items, "ip:3")
+}
@@ -16,6 +16,8 @@ const (
 
 var (
 	reNumeric = regexp.MustCompile(`^[0-9]+$`)
+	reHash    = regexp.MustCompile(`^[1-9A-HJ-NP-Za-km-z]{43,44}$`)
+	reAccount = regexp.MustCompile(`^(([a-z\d]+[-_])*[a-z\d]+\.)*([a-z\d]+[-_])*[a-z\d]+$`)
 )
 
 type rid struct {
@@ -31,6 +33,21 @@ func (r rid) IsString() bool {
 	return r.kind == ridString
 }
 
+// IsHash returns true if the ID looks like a base58 encoded block, transaction or epoch hash
+func (r rid) IsHash() bool {
+	return reHash.MatchString(r.raw)
+}
+
+// IsAccount returns true if the ID is a valid account name
+func (r rid) IsAccount() bool {
+	return len(r.raw) >= 2 && len(r.raw) <= 64 && reAccount.MatchString(r.raw)
+}
+
+// IsAccountPrefix returns true if the ID could be the beginning of an account name
+func (r rid) IsAccountPrefix() bool {
+	return len(r.raw) >= 2 && len(r.raw) <= 64 && reAccount.MatchString(strings.TrimRight(r.raw, "-_."))
+}
+
 func (r rid) String() string {
 	return r.raw
 }
@@ -46,7 +63,11 @@ func (r rid) UInt64() uint64 {
 }
 
 func resourceID(c *gin.Context, key string) rid {
-	val := strings.TrimSpace(c.Param(key))
+	return newResourceID(c.Param(key))
+}
+
+func newResourceID(val string) rid {
+	val = strings.TrimSpace(val)
 
 	id := rid{raw: val, kind: ridString}
 	if val == "" {
@@ -0,0 +1,412 @@
+package server
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/figment-networks/near-indexer/model"
+	"github.com/figment-networks/near-indexer/near"
+	"github.com/figment-networks/near-indexer/rosetta"
+	"github.com/figment-networks/near-indexer/store"
+)
+
+// rosettaNetwork caches the network and genesis block identifiers fetched from the node
+type rosettaNetwork struct {
+	sync.Mutex
+	identifier *rosetta.NetworkIdentifier
+	genesis    *rosetta.BlockIdentifier
+}
+
+// mountRosetta registers the Rosetta Data API routes
+func (s Server) mountRosetta() {
+	s.router.POST("/network/list", s.RosettaNetworkList)
+	s.router.POST("/network/status", s.RosettaNetworkStatus)
+	s.router.POST("/network/options", s.RosettaNetworkOptions)
+	s.router.POST("/block", s.RosettaBlock)
+	s.router.POST("/block/transaction", s.RosettaBlockTransaction)
+	s.router.POST("/account/balance", s.RosettaAccountBalance)
+	s.router.POST("/mempool", s.RosettaMempool)
+	s.router.POST("/mempool/transaction", s.RosettaMempoolTransaction)
+}
+
+// RosettaNetworkList renders the list of supported networks
+func (s Server) RosettaNetworkList(c *gin.Context) {
+	network, err := s.rosettaNetworkIdentifier()
+	if err != nil {
+		rosettaError(c, rosetta.ErrNodeUnavailable.WithDetails(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, rosetta.NetworkListResponse{
+		NetworkIdentifiers: []*rosetta.NetworkIdentifier{network},
+	})
+}
+
+// RosettaNetworkStatus renders the current network status
+func (s Server) RosettaNetworkStatus(c *gin.Context) {
+	req := rosetta.NetworkRequest{}
+	if !s.bindRosettaRequest(c, &req, &req.NetworkIdentifier) {
+		return
+	}
+
+	current, err := s.db.Blocks.Last()
+	if err != nil {
+		rosettaStoreError(c, err, rosetta.ErrBlockNotFound)
+		return
+	}
+
+	oldest, err := s.db.Blocks.First()
+	if err != nil {
+		rosettaStoreError(c, err, rosetta.ErrBlockNotFound)
+		return
+	}
+
+	genesis, err := s.rosettaGenesisIdentifier()
+	if err != nil {
+		rosettaError(c, rosetta.ErrNodeUnavailable.WithDetails(err))
+		return
+	}
+
+	info, err := s.rpc.NetworkInfo()
+	if err != nil {
+		rosettaError(c, rosetta.ErrNodeUnavailable.WithDetails(err))
+		return
+	}
+
+	peers := make([]*rosetta.Peer, len(info.ActivePeers))
+	for i, peer := range info.ActivePeers {
+		peers[i] = &rosetta.Peer{
+			PeerID:   peer.ID,
+			Metadata: map[string]interface{}{"address": peer.Address},
+		}
+	}
+
+	c.JSON(http.StatusOK, rosetta.NetworkStatusResponse{
+		CurrentBlockIdentifier: rosetta.NewBlockIdentifier(current),
+		CurrentBlockTimestamp:  current.Time.UnixNano() / 1e6,
+		GenesisBlockIdentifier: genesis,
+		OldestBlockIdentifier:  rosetta.NewBlockIdentifier(oldest),
+		Peers:                  peers,
+	})
+}
+
+// RosettaNetworkOptions renders the supported API options
+func (s Server) RosettaNetworkOptions(c *gin.Context) {
+	req := rosetta.NetworkRequest{}
+	if !s.bindRosettaRequest(c, &req, &req.NetworkIdentifier) {
+		return
+	}
+
+	status, err := s.rpc.Status()
+	if err != nil {
+		rosettaError(c, rosetta.ErrNodeUnavailable.WithDetails(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, rosetta.NetworkOptionsResponse{
+		Version: &rosetta.VersionInfo{
+			RosettaVersion: rosetta.Version,
+			NodeVersion:    status.Version.String(),
+		},
+		Allow: &rosetta.Allow{
+			OperationStatuses:       rosetta.OperationStatuses,
+			OperationTypes:          rosetta.OperationTypes,
+			Errors:                  rosetta.Errors,
+			HistoricalBalanceLookup: true,
+		},
+	})
+}
+
+// RosettaBlock renders a block with all its transactions
+func (s Server) RosettaBlock(c *gin.Context) {
+	req := rosetta.BlockRequest{}
+	if !s.bindRosettaRequest(c, &req, &req.NetworkIdentifier) {
+		return
+	}
+
+	block, err := s.findRosettaBlock(req.BlockIdentifier)
+	if err != nil {
+		rosettaStoreError(c, err, rosetta.ErrBlockNotFound)
+		return
+	}
+
+	parent, err := s.rosettaParentIdentifier(block.Hash)
+	if err != nil {
+		rosettaError(c, rosetta.ErrInternal.WithDetails(err))
+		return
+	}
+
+	transactions := []model.Transaction{}
+	if block.TransactionsCount > 0 {
+		transactions, err = s.db.Transactions.AllByBlock(block.Hash)
+		if err != nil {
+			rosettaError(c, rosetta.ErrInternal.WithDetails(err))
+			return
+		}
+	}
+
+	result, err := rosetta.NewBlock(block, parent, transactions, s.rosettaAccountLookup(parent.Hash))
+	if err != nil {
+		rosettaError(c, rosetta.ErrInternal.WithDetails(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, rosetta.BlockResponse{Block: result})
+}
+
+// RosettaBlockTransaction renders a single transaction of a block
+func (s Server) RosettaBlockTransaction(c *gin.Context) {
+	req := rosetta.BlockTransactionRequest{}
+	if !s.bindRosettaRequest(c, &req, &req.NetworkIdentifier) {
+		return
+	}
+	if req.BlockIdentifier == nil || req.TransactionIdentifier == nil {
+		rosettaError(c, rosetta.ErrRequestInvalid)
+		return
+	}
+
+	tx, err := s.db.Transactions.FindByHash(req.TransactionIdentifier.Hash)
+	if err != nil {
+		rosettaStoreError(c, err, rosetta.ErrTransactionNotFound)
+		return
+	}
+	if tx.BlockHash != req.BlockIdentifier.Hash {
+		rosettaError(c, rosetta.ErrTransactionNotFound)
+		return
+	}
+
+	parent, err := s.rosettaParentIdentifier(tx.BlockHash)
+	if err != nil {
+		rosettaError(c, rosetta.ErrInternal.WithDetails(err))
+		return
+	}
+
+	result, err := rosetta.NewTransaction(tx, s.rosettaAccountLookup(parent.Hash))
+	if err != nil {
+		rosettaError(c, rosetta.ErrInternal.WithDetails(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, rosetta.BlockTransactionResponse{Transaction: result})
+}
+
+// RosettaAccountBalance renders the account liquid balance at the given or latest block
+func (s Server) RosettaAccountBalance(c *gin.Context) {
+	req := rosetta.AccountBalanceRequest{}
+	if !s.bindRosettaRequest(c, &req, &req.NetworkIdentifier) {
+		return
+	}
+	if req.AccountIdentifier == nil || req.AccountIdentifier.Address == "" {
+		rosettaError(c, rosetta.ErrRequestInvalid)
+		return
+	}
+
+	var blockID interface{}
+	if req.BlockIdentifier != nil {
+		switch {
+		case req.BlockIdentifier.Hash != nil:
+			blockID = *req.BlockIdentifier.Hash
+		case req.BlockIdentifier.Index != nil:
+			blockID = *req.BlockIdentifier.Index
+		}
+	}
+	if blockID == nil {
+		last, err := s.db.Blocks.Last()
+		if err != nil {
+			rosettaStoreError(c, err, rosetta.ErrBlockNotFound)
+			return
+		}
+		blockID = last.Hash
+	}
+
+	account, err := s.rpc.AccountAtBlock(req.AccountIdentifier.Address, blockID)
+	if err != nil {
+		rosettaError(c, rosetta.ErrAccountNotFound.WithDetails(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, rosetta.AccountBalanceResponse{
+		BlockIdentifier: &rosetta.BlockIdentifier{
+			Index: int64(account.BlockHeight),
+			Hash:  account.BlockHash,
+		},
+		Balances: []*rosetta.Amount{
+			{Value: account.Amount, Currency: rosetta.Currency},
+		},
+		Metadata: map[string]interface{}{
+			"locked": account.Locked,
+		},
+	})
+}
+
+// RosettaMempool renders an empty mempool, pending transactions are not tracked
+func (s Server) RosettaMempool(c *gin.Context) {
+	req := rosetta.NetworkRequest{}
+	if !s.bindRosettaRequest(c, &req, &req.NetworkIdentifier) {
+		return
+	}
+
+	c.JSON(http.StatusOK, rosetta.MempoolResponse{
+		TransactionIdentifiers: []*rosetta.TransactionIdentifier{},
+	})
+}
+
+// RosettaMempoolTransaction always fails since pending transactions are not tracked
+func (s Server) RosettaMempoolTransaction(c *gin.Context) {
+	req := rosetta.MempoolTransactionRequest{}
+	if !s.bindRosettaRequest(c, &req, &req.NetworkIdentifier) {
+		return
+	}
+
+	rosettaError(c, rosetta.ErrMempoolTransactionGone)
+}
+
+// findRosettaBlock returns a block for a partial identifier, or the latest block
+func (s Server) findRosettaBlock(id *rosetta.PartialBlockIdentifier) (*model.Block, error) {
+	if id != nil {
+		if id.Hash != nil {
+			return s.db.Blocks.FindByHash(*id.Hash)
+		}
+		if id.Index != nil {
+			return s.db.Blocks.FindByHeight(uint64(*id.Index))
+		}
+	}
+	return s.db.Blocks.Last()
+}
+
+// bindRosettaRequest decodes the request body and checks the requested network
+func (s Server) bindRosettaRequest(c *gin.Context, req interface{}, network **rosetta.NetworkIdentifier) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		rosettaError(c, rosetta.ErrRequestInvalid.WithDetails(err))
+		return false
+	}
+
+	expected, err := s.rosettaNetworkIdentifier()
+	if err != nil {
+		rosettaError(c, rosetta.ErrNodeUnavailable.WithDetails(err))
+		return false
+	}
+
+	if *network == nil || **network != *expected {
+		rosettaError(c, rosetta.ErrNetworkNotSupported)
+		return false
+	}
+
+	return true
+}
+
+// rosettaNetworkIdentifier returns the network identifier of the connected node
+func (s Server) rosettaNetworkIdentifier() (*rosetta.NetworkIdentifier, error) {
+	s.rosetta.Lock()
+	defer s.rosetta.Unlock()
+
+	if s.rosetta.identifier != nil {
+		return s.rosetta.identifier, nil
+	}
+
+	status, err := s.rpc.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	s.rosetta.identifier = &rosetta.NetworkIdentifier{
+		Blockchain: rosetta.Blockchain,
+		Network:    status.ChainID,
+	}
+
+	return s.rosetta.identifier, nil
+}
+
+// rosettaGenesisIdentifier returns the genesis block identifier of the connected node
+func (s Server) rosettaGenesisIdentifier() (*rosetta.BlockIdentifier, error) {
+	s.rosetta.Lock()
+	defer s.rosetta.Unlock()
+
+	if s.rosetta.genesis != nil {
+		return s.rosetta.genesis, nil
+	}
+
+	genesis, err := s.rpc.GenesisConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	// Non-archival nodes do not serve the genesis block, unless it's been indexed
+	if indexed, err := s.db.Blocks.FindByHeight(genesis.GenesisHeight); err == nil {
+		s.rosetta.genesis = rosetta.NewBlockIdentifier(indexed)
+		return s.rosetta.genesis, nil
+	}
+
+	block, err := s.rpc.BlockByHeight(genesis.GenesisHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	s.rosetta.genesis = &rosetta.BlockIdentifier{
+		Index: int64(block.Header.Height),
+		Hash:  block.Header.Hash,
+	}
+
+	return s.rosetta.genesis, nil
+}
+
+// rosettaParentIdentifier returns the identifier of the parent block, read from the
+// block header since heights may be skipped. Genesis block is its own parent.
+func (s Server) rosettaParentIdentifier(hash string) (*rosetta.BlockIdentifier, error) {
+	genesis, err := s.rosettaGenesisIdentifier()
+	if err != nil {
+		return nil, err
+	}
+	if hash == genesis.Hash {
+		return genesis, nil
+	}
+
+	block, err := s.rpc.BlockByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if parent, err := s.db.Blocks.FindByHash(block.Header.PrevHash); err == nil {
+		return rosetta.NewBlockIdentifier(parent), nil
+	} else if err != store.ErrNotFound {
+		return nil, err
+	}
+
+	parent, err := s.rpc.BlockByHash(block.Header.PrevHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rosetta.BlockIdentifier{
+		Index: int64(parent.Header.Height),
+		Hash:  parent.Header.Hash,
+	}, nil
+}
+
+// rosettaAccountLookup returns a lookup of account states at the parent block,
+// i.e. before the transactions of the block are executed
+func (s Server) rosettaAccountLookup(parentHash string) rosetta.AccountLookup {
+	return func(account string) (*near.Account, error) {
+		result, err := s.rpc.AccountAtBlock(account, parentHash)
+		if err != nil {
+			return nil, err
+		}
+		return &result, nil
+	}
+}
+
+// rosettaError renders a Rosetta error response
+func rosettaError(c *gin.Context, err *rosetta.Error) {
+	c.AbortWithStatusJSON(http.StatusInternalServerError, err)
+}
+
+// rosettaStoreError renders a not found or internal error for a store lookup error
+func rosettaStoreError(c *gin.Context, err error, notFound *rosetta.Error) {
+	if err == store.ErrNotFound {
+		rosettaError(c, notFound)
+		return
+	}
+	rosettaError(c, rosetta.ErrInternal.WithDetails(err))
+}
@@ -0,0 +1,169 @@
+package server
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/figment-networks/near-indexer/store"
+)
+
+const (
+	searchTypeBlock       = "block"
+	searchTypeTransaction = "transaction"
+	searchTypeEpoch       = "epoch"
+	searchTypeValidator   = "validator"
+	searchTypeAccount     = "account"
+)
+
+// searchParams contains the search query
+type searchParams struct {
+	Query string `form:"q" binding:"required"`
+	Limit uint   `form:"limit"`
+}
+
+// searchResult is a single record matching the search query
+type searchResult struct {
+	Type string      `json:"type"`
+	ID   string      `json:"id"`
+	Data interface{} `json:"data"`
+}
+
+// searchResponse contains all records matching the search query
+type searchResponse struct {
+	Query   string         `json:"query"`
+	Results []searchResult `json:"results"`
+}
+
+// searchLookup finds records matching the query
+type searchLookup func() ([]searchResult, error)
+
+func (p *searchParams) setDefaults() {
+	if p.Limit == 0 {
+		p.Limit = 10
+	}
+	if p.Limit > 50 {
+		p.Limit = 50
+	}
+}
+
+// GetSearch finds blocks, transactions, epochs, validators and accounts matching the query
+func (s Server) GetSearch(c *gin.Context) {
+	params := searchParams{}
+	if err := c.BindQuery(&params); err != nil {
+		badRequest(c, err)
+		return
+	}
+	params.setDefaults()
+
+	query := newResourceID(params.Query)
+	lookups := s.searchLookups(query, params.Limit)
+
+	results := make([][]searchResult, len(lookups))
+	errs := make([]error, len(lookups))
+
+	wg := sync.WaitGroup{}
+	for i, lookup := range lookups {
+		wg.Add(1)
+		go func(i int, lookup searchLookup) {
+			defer wg.Done()
+			results[i], errs[i] = lookup()
+		}(i, lookup)
+	}
+	wg.Wait()
+
+	response := searchResponse{
+		Query:   query.String(),
+		Results: []searchResult{},
+	}
+
+	// Results are listed in the lookups order, prefix matches are sorted by name
+	for i := range lookups {
+		if errs[i] != nil && errs[i] != store.ErrNotFound {
+			serverError(c, errs[i])
+			return
+		}
+		response.Results = append(response.Results, results[i]...)
+	}
+
+	jsonOk(c, response)
+}
+
+// searchLookups returns store lookups matching the kind of the query
+func (s Server) searchLookups(query rid, limit uint) []searchLookup {
+	lookups := []searchLookup{}
+
+	// Numeric values out of the height range could only be implicit account names
+	_, heightErr := strconv.ParseUint(query.String(), 10, 64)
+	isHeight := query.IsNumeric() && heightErr == nil
+
+	if isHeight {
+		lookups = append(lookups, func() ([]searchResult, error) {
+			block, err := s.db.Blocks.FindByHeight(query.UInt64())
+			if err != nil {
+				return nil, err
+			}
+			return []searchResult{{Type: searchTypeBlock, ID: block.Hash, Data: block}}, nil
+		})
+	}
+
+	if query.IsHash() {
+		lookups = append(lookups,
+			func() ([]searchResult, error) {
+				block, err := s.db.Blocks.FindByHash(query.String())
+				if err != nil {
+					return nil, err
+				}
+				return []searchResult{{Type: searchTypeBlock, ID: block.Hash, Data: block}}, nil
+			},
+			func() ([]searchResult, error) {
+				tx, err := s.db.Transactions.FindByHash(query.String())
+				if err != nil {
+					return nil, err
+				}
+				return []searchResult{{Type: searchTypeTransaction, ID: tx.Hash, Data: tx}}, nil
+			},
+			func() ([]searchResult, error) {
+				epoch, err := s.db.Epochs.FindByID(query.String())
+				if err != nil {
+					return nil, err
+				}
+				return []searchResult{{Type: searchTypeEpoch, ID: epoch.ID, Data: epoch}}, nil
+			},
+		)
+	}
+
+	// Account names are always lowercase
+	account := newResourceID(strings.ToLower(query.String()))
+
+	if !isHeight && account.IsAccountPrefix() {
+		lookups = append(lookups,
+			func() ([]searchResult, error) {
+				validators, err := s.db.ValidatorAggs.SearchByPrefix(account.String(), limit)
+				if err != nil {
+					return nil, err
+				}
+				results := []searchResult{}
+				for i := range validators {
+					results = append(results, searchResult{Type: searchTypeValidator, ID: validators[i].AccountID, Data: validators[i]})
+				}
+				return results, nil
+			},
+			func() ([]searchResult, error) {
+				accounts, err := s.db.Accounts.SearchByPrefix(account.String(), limit)
+				if err != nil {
+					return nil, err
+				}
+				results := []searchResult{}
+				for i := range accounts {
+					results = append(results, searchResult{Type: searchTypeAccount, ID: accounts[i].Name, Data: accounts[i]})
+				}
+				return results, nil
+			},
+		)
+	}
+
+	return lookups
+}
@@ -1,47 +1,78 @@
 package server
 
 import (
+	"encoding/json"
 	"errors"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 
 	"github.com/figment-networks/near-indexer/config"
+	"github.com/figment-networks/near-indexer/graph"
 	"github.com/figment-networks/near-indexer/model"
 	"github.com/figment-networks/near-indexer/model/mapper"
 	"github.com/figment-networks/near-indexer/model/types"
 	"github.com/figment-networks/near-indexer/near"
+	"github.com/figment-networks/near-indexer/prices"
 	"github.com/figment-networks/near-indexer/store"
 )
 
 // Server handles all HTTP calls
 type Server struct {
-	router *gin.Engine
-	db     *store.Store
-	rpc    near.Client
-	log    *logrus.Logger
+	router  *gin.Engine
+	db      *store.Store
+	rpc     near.Client
+	log     *logrus.Logger
+	prices  prices.Source
+	stream  *streamHub
+	rosetta *rosettaNetwork
+	docs    *apiDocs
+	auth    *apiAuth
+	cache   *responseCache
 }
 
 // New returns a new server
-func New(cfg *config.Config, db *store.Store, logger *logrus.Logger, rpc near.Client) Server {
+func New(cfg *config.Config, db *store.Store, logger *logrus.Logger, rpc near.Client, priceSource prices.Source) Server {
 	router := gin.New()
+	// Forwarded headers are only trusted from the configured proxies, see clientIP
+	router.ForwardedByClientIP = false
 	router.Use(gin.Recovery())
-	router.Use(requestLogger(logger))
+	router.Use(requestLogger(logger, cfg.TrustedProxyNetworks()))
 
 	if cfg.RollbarToken != "" {
 		router.Use(RollbarMiddleware())
 	}
 
 	s := Server{
-		router: router,
-		db:     db,
-		rpc:    rpc,
-		log:    logger,
+		router:  router,
+		db:      db,
+		rpc:     rpc,
+		log:     logger,
+		prices:  priceSource,
+		stream:  newStreamHub(db.Primary(), logger),
+		rosetta: &rosettaNetwork{},
+		docs:    newAPIDocs(apiOperations),
+		auth:    newAPIAuth(cfg, db, logger),
 	}
+	s.auth.usage.Start()
+
+	router.Use(s.auth.handler())
+	router.Use(validateParams(s.docs))
+
+	if cfg.ResponseCache {
+		s.cache = newResponseCache(db.Primary(), cacheTTLs, s.stream.Connected, cfg.APIKeysRequired)
+		s.stream.OnBlock(s.cache.SetHeight)
+		router.Use(s.cache.handler())
+	}
+
+	s.stream.Listen(cfg.DatabaseURL)
 
 	router.GET("/", s.GetEndpoints)
+	router.GET("/openapi.json", s.GetOpenAPI)
+	router.GET("/docs", s.GetDocs)
 	router.GET("/health", s.GetHealth)
 	router.GET("/status", s.GetStatus)
 	router.GET("/height", s.GetHeight)
@@ -50,20 +81,57 @@ func New(cfg *config.Config, db *store.Store, logger *logrus.Logger, rpc near.Cl
 	router.GET("/block", s.GetRecentBlock)
 	router.GET("/blocks", s.GetBlocks)
 	router.GET("/blocks/:id", s.GetBlock)
+	router.GET("/blocks/:id/transactions", s.GetBlockTransactions)
 	router.GET("/block_times", s.GetBlockTimes)
 	router.GET("/block_stats", s.GetBlockStats)
+	router.GET("/validator_stats", s.GetValidatorStats)
+	router.GET("/gas", s.GetGas)
 	router.GET("/validators", s.GetValidators)
 	router.GET("/validators/:id", s.GetValidator)
 	router.GET("/validators/:id/epochs", s.GetValidatorEpochs)
 	router.GET("/validators/:id/events", s.GetValidatorEvents)
+	router.GET("/validators/:id/delegators", s.GetValidatorDelegators)
 	router.GET("/delegators/:id/rewards", s.GetDelegatorRewards)
+	router.GET("/delegators/:id/tax_report", s.GetDelegatorTaxReport)
 	router.GET("/transactions", s.GetTransactions)
 	router.GET("/transactions/:id", s.GetTransaction)
+	router.GET("/transaction_stats", s.GetTransactionStats)
 	router.GET("/accounts/:id", s.GetAccount)
+	router.GET("/accounts/:id/activity", s.GetAccountActivity)
+	router.GET("/search", s.GetSearch)
 	router.GET("/delegations/:id", s.GetDelegations)
 	router.GET("/delegators", s.GetDelegators)
 	router.GET("/events", s.GetEvents)
 	router.GET("/events/:id", s.GetEvent)
+	router.GET("/network/economics", s.GetNetworkEconomics)
+	router.GET("/network/active_accounts", s.GetActiveAccounts)
+	router.GET("/stream", s.GetStream)
+	router.GET("/stream/sse", s.GetStreamSSE)
+
+	// Webhooks are managed by their owners, identified by the API key
+	webhooks := router.Group("/webhooks", requireAPIKey)
+	webhooks.GET("", s.GetWebhooks)
+	webhooks.POST("", s.CreateWebhook)
+	webhooks.GET("/:id", s.GetWebhook)
+	webhooks.PUT("/:id", s.UpdateWebhook)
+	webhooks.DELETE("/:id", s.DeleteWebhook)
+	webhooks.GET("/:id/deliveries", s.GetWebhookDeliveries)
+
+	router.GET("/admin/usage", s.GetAdminUsage)
+
+	router.GET("/export/transactions", s.GetTransactionsExport)
+	router.GET("/export/delegator_rewards", s.GetDelegatorRewardsExport)
+	router.GET("/export/validator_epochs", s.GetValidatorEpochsExport)
+
+	graphqlHandler := gin.WrapH(graph.NewHandler(db))
+	router.GET("/graphql", graphqlHandler)
+	router.POST("/graphql", graphqlHandler)
+
+	if cfg.RosettaEnabled {
+		s.mountRosetta()
+	}
+
+	s.docs.setRoutes(router.Routes())
 
 	return s
 }
@@ -75,30 +143,23 @@ func (s Server) Run(addr string) error {
 
 // GetEndpoints returns a list of all available endpoints
 func (s Server) GetEndpoints(c *gin.Context) {
+	endpoints := gin.H{}
+
+	for _, key := range s.docs.paths() {
+		summary := ""
+		if op := s.docs.operations[key]; op != nil {
+			summary = op.Summary
+		}
+
+		// GET endpoints are listed by path only
+		if strings.HasPrefix(key, "GET ") {
+			key = strings.TrimPrefix(key, "GET ")
+		}
+		endpoints[key] = summary
+	}
+
 	jsonOk(c, gin.H{
-		"endpoints": gin.H{
-			"/health":                 "Get service health",
-			"/status":                 "Get service and network status",
-			"/height":                 "Get current block height",
-			"/block":                  "Get current block details",
-			"/blocks":                 "Get latest blocks",
-			"/blocks/:id":             "Get block details by height or hash",
-			"/block_times":            "Get average block times",
-			"/block_stats":            "Get block stats for a time bucket",
-			"/epochs":                 "Get list of epochs",
-			"/epochs/:id":             "Get epoch details",
-			"/validators":             "List all validators",
-			"/validators/:id":         "Get validator details",
-			"/validators/:id/epochs":  "Get validator epochs performance",
-			"/validators/:id/events":  "Get validator events",
-			"/delegators/:id/rewards": "Get delegator rewards",
-			"/transactions":           "List all recent transactions",
-			"/transactions/:id":       "Get transaction details",
-			"/accounts/:id":           "Get account details",
-			"/delegations/:id":        "Get account delegations",
-			"/events":                 "Get list of events",
-			"/events/:id":             "Get event details",
-		},
+		"endpoints": endpoints,
 	})
 }
 
@@ -134,6 +195,16 @@ func (s Server) GetStatus(c *gin.Context) {
 		}
 	}
 
+	if replicas := s.db.Replicas(); len(replicas) > 0 {
+		// Connection errors may reveal database hosts, so only admin keys get them
+		if key := requestAPIKey(c); key == nil || !key.Admin {
+			for i := range replicas {
+				replicas[i].Error = ""
+			}
+		}
+		data["read_replicas"] = replicas
+	}
+
 	if status, err := s.rpc.Status(); err == nil {
 		data["network_name"] = status.ChainID
 		data["network_version"] = status.Version.Version
@@ -158,7 +229,7 @@ func (s Server) GetHeight(c *gin.Context) {
 
 // GetEpochs returns a list of recent epochs
 func (s Server) GetEpochs(c *gin.Context) {
-	epochs, err := s.db.Epochs.Recent(100)
+	epochs, err := s.requestStore(c).Epochs.Recent(100)
 	if shouldReturn(c, err) {
 		return
 	}
@@ -167,6 +238,12 @@ func (s Server) GetEpochs(c *gin.Context) {
 
 // GetEpoch returns a single epoch details
 func (s Server) GetEpoch(c *gin.Context) {
+	// Gin does not allow static and wildcard routes on the same path segment
+	if c.Param("id") == "stats" {
+		s.GetEpochStats(c)
+		return
+	}
+
 	epoch, err := s.db.Epochs.FindByID(c.Param("id"))
 	if shouldReturn(c, err) {
 		return
@@ -174,6 +251,23 @@ func (s Server) GetEpoch(c *gin.Context) {
 	jsonOk(c, epoch)
 }
 
+// GetEpochStats returns a time series of epochs stake and supply stats
+func (s Server) GetEpochStats(c *gin.Context) {
+	params := epochStatsParams{}
+	if err := c.BindQuery(&params); err != nil {
+		badRequest(c, err)
+		return
+	}
+	params.setDefaults()
+
+	result, err := s.db.Epochs.Stats(params.Limit)
+	if shouldReturn(c, err) {
+		return
+	}
+
+	jsonOk(c, result)
+}
+
 // GetRecentBlock renders the last indexed block
 func (s Server) GetRecentBlock(c *gin.Context) {
 	block, err := s.db.Blocks.Last()
@@ -185,29 +279,127 @@ func (s Server) GetRecentBlock(c *gin.Context) {
 
 // GetBlocks renders blocks that match search params
 func (s Server) GetBlocks(c *gin.Context) {
-	blocks, err := s.db.Blocks.Search()
+	search := store.BlocksSearch{}
+	if err := c.BindQuery(&search); err != nil {
+		badRequest(c, err)
+		return
+	}
+	if err := search.Validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	blocks, err := s.db.Blocks.Search(search)
 	if shouldReturn(c, err) {
 		return
 	}
 	jsonOk(c, blocks)
 }
 
-// GetBlock renders a block for a given height or hash
-func (s Server) GetBlock(c *gin.Context) {
-	var block *model.Block
-	var err error
+// blockDetails contains the block along with its adjacent blocks and included data
+type blockDetails struct {
+	*model.Block
+
+	Previous     *model.BlockLink    `json:"previous"`
+	Next         *model.BlockLink    `json:"next"`
+	Transactions []model.Transaction `json:"transactions,omitempty"`
+	Chunks       []near.BlockChunk   `json:"chunks,omitempty"`
+	Events       []model.Event       `json:"events,omitempty"`
+}
 
+// findBlock returns a block for the height or hash provided in the path
+func (s Server) findBlock(c *gin.Context) (*model.Block, error) {
 	rid := resourceID(c, "id")
 	if rid.IsNumeric() {
-		block, err = s.db.Blocks.FindByHeight(rid.UInt64())
-	} else {
-		block, err = s.db.Blocks.FindByHash(rid.String())
+		return s.db.Blocks.FindByHeight(rid.UInt64())
 	}
+	return s.db.Blocks.FindByHash(rid.String())
+}
+
+// GetBlock renders a block for a given height or hash
+func (s Server) GetBlock(c *gin.Context) {
+	params := blockParams{}
+	if err := c.BindQuery(&params); err != nil {
+		badRequest(c, err)
+		return
+	}
+	if err := params.Validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	block, err := s.findBlock(c)
 	if shouldReturn(c, err) {
 		return
 	}
 
-	jsonOk(c, block)
+	details := blockDetails{Block: block}
+
+	if prev, err := s.db.Blocks.FindPrevious(uint64(block.ID)); err == nil {
+		details.Previous = prev.Link()
+	} else if err != store.ErrNotFound {
+		serverError(c, err)
+		return
+	}
+
+	if next, err := s.db.Blocks.FindNext(uint64(block.ID)); err == nil {
+		details.Next = next.Link()
+	} else if err != store.ErrNotFound {
+		serverError(c, err)
+		return
+	}
+
+	if params.Includes("transactions") {
+		details.Transactions, err = s.db.Transactions.FindByBlock(block.Hash)
+		if shouldReturn(c, err) {
+			return
+		}
+	}
+
+	// Chunks are fetched from the node and could be missing for old blocks
+	// on non-archival nodes, in which case they're omitted from the response
+	if params.Includes("chunks") {
+		if rpcBlock, err := s.rpc.BlockByHash(block.Hash); err == nil {
+			details.Chunks = rpcBlock.Chunks
+		} else {
+			s.log.WithError(err).WithField("hash", block.Hash).Warn("block chunks fetch failed")
+		}
+	}
+
+	if params.Includes("events") {
+		details.Events, err = s.db.Events.FindByHeight(uint64(block.ID))
+		if shouldReturn(c, err) {
+			return
+		}
+	}
+
+	jsonOk(c, details)
+}
+
+// GetBlockTransactions renders transactions included in a block
+func (s Server) GetBlockTransactions(c *gin.Context) {
+	search := store.TransactionsSearch{}
+	if err := c.BindQuery(&search.Pagination); err != nil {
+		badRequest(c, err)
+		return
+	}
+	if err := search.Validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	block, err := s.findBlock(c)
+	if shouldReturn(c, err) {
+		return
+	}
+	search.BlockHash = block.Hash
+
+	transactions, err := s.db.Transactions.Search(search)
+	if shouldReturn(c, err) {
+		return
+	}
+
+	jsonOk(c, transactions)
 }
 
 // GetBlockTimes returns an average block time for the last N blocks
@@ -220,7 +412,7 @@ func (s Server) GetBlockTimes(c *gin.Context) {
 	}
 	params.setDefaults()
 
-	result, err := s.db.Blocks.BlockTimes(params.Limit)
+	result, err := s.requestStore(c).Blocks.BlockTimes(params.Limit)
 	if err != nil {
 		badRequest(c, err)
 		return
@@ -231,6 +423,36 @@ func (s Server) GetBlockTimes(c *gin.Context) {
 
 // GetBlockStats returns block stats for a given time bucket
 func (s Server) GetBlockStats(c *gin.Context) {
+	params := blockStatsParams{}
+	if err := c.BindQuery(&params); err != nil {
+		badRequest(c, err)
+		return
+	}
+	if err := params.Validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	var (
+		result []byte
+		err    error
+	)
+
+	if params.HasRange() {
+		timeRange := store.TimeRange{Start: params.From, End: params.To}
+		result, err = s.requestStore(c).Blocks.BlockStatsRange(params.Bucket, timeRange)
+	} else {
+		result, err = s.requestStore(c).Blocks.BlockStats(params.Bucket, params.Limit)
+	}
+	if shouldReturn(c, err) {
+		return
+	}
+
+	jsonOk(c, result)
+}
+
+// GetValidatorStats returns validator stats for a given time bucket
+func (s Server) GetValidatorStats(c *gin.Context) {
 	params := statsParams{}
 	if err := c.BindQuery(&params); err != nil {
 		badRequest(c, err)
@@ -241,7 +463,7 @@ func (s Server) GetBlockStats(c *gin.Context) {
 		return
 	}
 
-	result, err := s.db.Blocks.BlockStats(params.Bucket, params.Limit)
+	result, err := s.db.Validators.ValidatorStats(params.Bucket, params.Limit)
 	if shouldReturn(c, err) {
 		return
 	}
@@ -249,9 +471,37 @@ func (s Server) GetBlockStats(c *gin.Context) {
 	jsonOk(c, result)
 }
 
+// GetGas returns the current gas price and gas stats for a given time bucket
+func (s Server) GetGas(c *gin.Context) {
+	params := gasStatsParams{}
+	if err := c.BindQuery(&params); err != nil {
+		badRequest(c, err)
+		return
+	}
+	if err := params.Validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	gasPrice, err := s.rpc.GasPrice("")
+	if shouldReturn(c, err) {
+		return
+	}
+
+	stats, err := s.db.Blocks.GasStats(params.Bucket, params.Limit)
+	if shouldReturn(c, err) {
+		return
+	}
+
+	jsonOk(c, gin.H{
+		"gas_price": gasPrice,
+		"stats":     json.RawMessage(stats),
+	})
+}
+
 // GetValidators returns recent validators
 func (s Server) GetValidators(c *gin.Context) {
-	validators, err := s.db.ValidatorAggs.Top()
+	validators, err := s.requestStore(c).ValidatorAggs.Top()
 	if shouldReturn(c, err) {
 		return
 	}
@@ -270,6 +520,10 @@ func (s Server) GetValidatorEpochs(c *gin.Context) {
 		badRequest(c, err)
 		return
 	}
+	if err := pagination.Validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
 
 	result, err := s.db.ValidatorAggs.PaginateValidatorEpochs(validator.AccountID, pagination)
 	if shouldReturn(c, err) {
@@ -295,6 +549,10 @@ func (s Server) GetValidatorEvents(c *gin.Context) {
 		badRequest(c, err)
 		return
 	}
+	if err := search.Validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
 
 	events, err := s.db.Events.Search(search)
 	if shouldReturn(c, err) {
@@ -304,6 +562,33 @@ func (s Server) GetValidatorEvents(c *gin.Context) {
 	jsonOk(c, events)
 }
 
+// GetValidatorDelegators returns a paginated list of validator delegators
+func (s Server) GetValidatorDelegators(c *gin.Context) {
+	validator, err := s.db.ValidatorAggs.FindBy("account_id", c.Param("id"))
+	if shouldReturn(c, err) {
+		return
+	}
+
+	search := store.ValidatorDelegatorsSearch{}
+	if err := c.BindQuery(&search); err != nil {
+		badRequest(c, err)
+		return
+	}
+	search.ValidatorID = validator.AccountID
+
+	if err := search.Validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	result, err := s.db.Delegators.PaginateValidatorDelegators(search)
+	if shouldReturn(c, err) {
+		return
+	}
+
+	jsonOk(c, result)
+}
+
 // GetDelegatorRewards returns delegator rewards
 func (s Server) GetDelegatorRewards(c *gin.Context) {
 	var params delegatorRewardsParams
@@ -340,6 +625,12 @@ func (s Server) GetDelegatorRewards(c *gin.Context) {
 
 // GetValidator returns validator details
 func (s Server) GetValidator(c *gin.Context) {
+	// Gin does not allow static and wildcard routes on the same path segment
+	if c.Param("id") == "next" {
+		s.GetNextValidators(c)
+		return
+	}
+
 	info, err := s.db.ValidatorAggs.FindBy("account_id", c.Param("id"))
 	if shouldReturn(c, err) {
 		return
@@ -359,7 +650,10 @@ func (s Server) GetValidator(c *gin.Context) {
 		return
 	}
 
-	blocks, err := s.db.Blocks.Search()
+	blocks, err := s.db.Blocks.Search(store.BlocksSearch{
+		Producer:   info.AccountID,
+		Pagination: store.Pagination{Limit: 50},
+	})
 	if shouldReturn(c, err) {
 		return
 	}
@@ -372,15 +666,31 @@ func (s Server) GetValidator(c *gin.Context) {
 		return
 	}
 
+	proposals, err := s.db.ValidatorProposals.Recent(info.AccountID, 10)
+	if shouldReturn(c, err) {
+		return
+	}
+
 	jsonOk(c, gin.H{
 		"validator": info,
 		"account":   account,
-		"blocks":    blocks,
+		"blocks":    blocks.Records,
 		"epochs":    epochs,
 		"events":    events.Records,
+		"proposals": proposals,
 	})
 }
 
+// GetNextValidators returns the projected validator set for the next epoch
+func (s Server) GetNextValidators(c *gin.Context) {
+	validators, err := s.rpc.CurrentValidators()
+	if shouldReturn(c, err) {
+		return
+	}
+
+	jsonOk(c, mapper.NextValidatorSet(validators))
+}
+
 // GetValidatorsByHeight renders the validators list for a height
 func (s Server) GetValidatorsByHeight(c *gin.Context) {
 	height := types.HeightFromString(c.Query("height"))
@@ -407,6 +717,10 @@ func (s Server) GetTransactions(c *gin.Context) {
 		badRequest(c, err)
 		return
 	}
+	if err := search.Validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
 
 	transactions, err := s.db.Transactions.Search(search)
 	if shouldReturn(c, err) {
@@ -426,6 +740,26 @@ func (s Server) GetTransaction(c *gin.Context) {
 	jsonOk(c, tx)
 }
 
+// GetTransactionStats returns transaction stats for a given time bucket
+func (s Server) GetTransactionStats(c *gin.Context) {
+	params := transactionStatsParams{}
+	if err := c.BindQuery(&params); err != nil {
+		badRequest(c, err)
+		return
+	}
+	if err := params.Validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	result, err := s.db.Transactions.TransactionStats(params.Bucket, params.Limit)
+	if shouldReturn(c, err) {
+		return
+	}
+
+	jsonOk(c, result)
+}
+
 // GetAccount returns an account by name
 func (s Server) GetAccount(c *gin.Context) {
 	account, err := s.rpc.Account(c.Param("id"))
@@ -436,6 +770,29 @@ func (s Server) GetAccount(c *gin.Context) {
 	jsonOk(c, account)
 }
 
+// GetAccountActivity renders transactions, receipts, staking actions, rewards
+// and validator events of an account, most recent first
+func (s Server) GetAccountActivity(c *gin.Context) {
+	search := store.ActivitySearch{}
+	if err := c.BindQuery(&search); err != nil {
+		badRequest(c, err)
+		return
+	}
+	search.Account = c.Param("id")
+
+	if err := search.Validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	activity, err := s.db.AccountActivity.Search(search)
+	if shouldReturn(c, err) {
+		return
+	}
+
+	jsonOk(c, activity)
+}
+
 // GetDelegations returns list of delegations for a given account
 func (s Server) GetDelegations(c *gin.Context) {
 	var (
@@ -471,6 +828,10 @@ func (s Server) GetDelegators(c *gin.Context) {
 		badRequest(c, err)
 		return
 	}
+	if err := params.Validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
 
 	delegatorEpochs, err := s.db.Delegators.SearchDelegatorEpochs(params)
 	if shouldReturn(c, err) {
@@ -491,6 +852,10 @@ func (s Server) GetEvents(c *gin.Context) {
 		badRequest(c, err)
 		return
 	}
+	if err := search.Validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
 
 	events, err := s.db.Events.Search(search)
 	if shouldReturn(c, err) {
@@ -514,3 +879,49 @@ func (s Server) GetEvent(c *gin.Context) {
 
 	jsonOk(c, event)
 }
+
+// GetNetworkEconomics returns network supply changes for a time interval
+func (s Server) GetNetworkEconomics(c *gin.Context) {
+	var params networkEconomicsParams
+	if err := c.BindQuery(&params); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	if err := params.Validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	interval, _ := model.GetTypeForTimeInterval(params.Interval)
+
+	result, err := s.db.Epochs.EconomicsByInterval(params.From, params.To, interval)
+	if shouldReturn(c, err) {
+		return
+	}
+
+	jsonOk(c, result)
+}
+
+// GetActiveAccounts returns active accounts counts for a time interval
+func (s Server) GetActiveAccounts(c *gin.Context) {
+	var params activeAccountsParams
+	if err := c.BindQuery(&params); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	if err := params.Validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	interval, _ := model.GetTypeForTimeInterval(params.Interval)
+
+	result, err := s.db.Accounts.ActiveByInterval(params.From, params.To, interval)
+	if shouldReturn(c, err) {
+		return
+	}
+
+	jsonOk(c, result)
+}
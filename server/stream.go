@@ -0,0 +1,319 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/websocket"
+
+	"github.com/figment-networks/near-indexer/model"
+	"github.com/figment-networks/near-indexer/store"
+)
+
+const (
+	streamTypeBlock       = "block"
+	streamTypeTransaction = "transaction"
+	streamTypeEvent       = "event"
+
+	// streamBufferSize is the number of messages queued for a slow subscriber
+	streamBufferSize = 256
+
+	streamMinReconnect = 10 * time.Second
+	streamMaxReconnect = time.Minute
+	streamPingInterval = 30 * time.Second
+)
+
+// streamMessage is sent to the stream subscribers
+type streamMessage struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+// streamFilter selects the messages delivered to a subscriber
+type streamFilter struct {
+	Types    string `form:"types"`
+	Accounts string `form:"accounts"`
+
+	types    map[string]bool
+	accounts map[string]bool
+}
+
+// streamSubscriber receives messages matching its filter
+type streamSubscriber struct {
+	filter   streamFilter
+	messages chan streamMessage
+}
+
+// streamHub receives new block notifications and fans out the indexed data to subscribers
+type streamHub struct {
+	db  *store.Store
+	log *logrus.Logger
+
+	mu          sync.RWMutex
+	subscribers map[*streamSubscriber]bool
+	handlers    []func(height uint64)
+	connected   int32
+}
+
+func (f *streamFilter) Validate() error {
+	f.types = map[string]bool{}
+	f.accounts = map[string]bool{}
+
+	if f.Types == "" {
+		f.Types = strings.Join([]string{streamTypeBlock, streamTypeTransaction, streamTypeEvent}, ",")
+	}
+
+	for _, t := range strings.Split(f.Types, ",") {
+		switch t {
+		case streamTypeBlock, streamTypeTransaction, streamTypeEvent:
+			f.types[t] = true
+		default:
+			return errors.New("invalid stream type: " + t)
+		}
+	}
+
+	if f.Accounts != "" {
+		for _, account := range strings.Split(f.Accounts, ",") {
+			f.accounts[account] = true
+		}
+	}
+
+	return nil
+}
+
+// Match returns true if the message should be delivered
+func (f streamFilter) Match(msg streamMessage) bool {
+	if !f.types[msg.Type] {
+		return false
+	}
+
+	if tx, ok := msg.Data.(model.Transaction); ok && len(f.accounts) > 0 {
+		return f.accounts[tx.Sender] || f.accounts[tx.Receiver]
+	}
+
+	return true
+}
+
+func newStreamHub(db *store.Store, logger *logrus.Logger) *streamHub {
+	return &streamHub{
+		db:          db,
+		log:         logger,
+		subscribers: map[*streamSubscriber]bool{},
+	}
+}
+
+// OnBlock registers a handler called for every new block notification.
+// Handlers must be registered before the hub starts listening.
+func (h *streamHub) OnBlock(handler func(height uint64)) {
+	h.handlers = append(h.handlers, handler)
+}
+
+// Connected returns true if the hub is receiving notifications
+func (h *streamHub) Connected() bool {
+	return atomic.LoadInt32(&h.connected) == 1
+}
+
+// Listen starts receiving new block notifications from the database
+func (h *streamHub) Listen(databaseURL string) {
+	listener := pq.NewListener(databaseURL, streamMinReconnect, streamMaxReconnect, func(ev pq.ListenerEventType, err error) {
+		switch ev {
+		case pq.ListenerEventConnected, pq.ListenerEventReconnected:
+			atomic.StoreInt32(&h.connected, 1)
+		case pq.ListenerEventDisconnected, pq.ListenerEventConnectionAttemptFailed:
+			atomic.StoreInt32(&h.connected, 0)
+		}
+		if err != nil {
+			h.log.WithError(err).Warn("stream listener error")
+		}
+	})
+
+	if err := listener.Listen(store.BlocksChannel); err != nil {
+		h.log.WithError(err).Error("stream listener failed to start")
+		return
+	}
+
+	go func() {
+		for n := range listener.Notify {
+			// Notify channel receives nil after the connection is re-established
+			if n == nil {
+				continue
+			}
+
+			notification := store.BlockNotification{}
+			if err := json.Unmarshal([]byte(n.Extra), &notification); err != nil {
+				h.log.WithError(err).Warn("invalid block notification")
+				continue
+			}
+
+			for _, handler := range h.handlers {
+				handler(notification.Height)
+			}
+
+			if h.count() > 0 {
+				h.publishBlock(notification.Height)
+			}
+		}
+	}()
+}
+
+// Subscribe registers a new subscriber
+func (h *streamHub) Subscribe(filter streamFilter) *streamSubscriber {
+	sub := &streamSubscriber{
+		filter:   filter,
+		messages: make(chan streamMessage, streamBufferSize),
+	}
+
+	h.mu.Lock()
+	h.subscribers[sub] = true
+	h.mu.Unlock()
+
+	return sub
+}
+
+// Unsubscribe removes the subscriber
+func (h *streamHub) Unsubscribe(sub *streamSubscriber) {
+	h.mu.Lock()
+	delete(h.subscribers, sub)
+	h.mu.Unlock()
+}
+
+func (h *streamHub) count() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.subscribers)
+}
+
+// publishBlock loads the block data once and broadcasts it to all subscribers
+func (h *streamHub) publishBlock(height uint64) {
+	block, err := h.db.Blocks.FindByHeight(height)
+	if err != nil {
+		h.log.WithError(err).WithField("height", height).Warn("stream block lookup failed")
+		return
+	}
+
+	messages := []streamMessage{{Type: streamTypeBlock, Data: block}}
+
+	transactions, err := h.db.Transactions.FindByBlock(block.Hash)
+	if err != nil {
+		h.log.WithError(err).WithField("height", height).Warn("stream transactions lookup failed")
+	}
+	for _, tx := range transactions {
+		messages = append(messages, streamMessage{Type: streamTypeTransaction, Data: tx})
+	}
+
+	events, err := h.db.Events.Search(store.EventsSearch{Height: height})
+	if err != nil {
+		h.log.WithError(err).WithField("height", height).Warn("stream events lookup failed")
+	} else if records, ok := events.Records.([]model.Event); ok {
+		for _, event := range records {
+			messages = append(messages, streamMessage{Type: streamTypeEvent, Data: event})
+		}
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for sub := range h.subscribers {
+		for _, msg := range messages {
+			if !sub.filter.Match(msg) {
+				continue
+			}
+
+			// Drop messages for subscribers that can't keep up
+			select {
+			case sub.messages <- msg:
+			default:
+			}
+		}
+	}
+}
+
+// GetStream streams new blocks, transactions and events over a WebSocket connection
+func (s Server) GetStream(c *gin.Context) {
+	filter := streamFilter{}
+	if err := c.BindQuery(&filter); err != nil {
+		badRequest(c, err)
+		return
+	}
+	if err := filter.Validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	wsServer := websocket.Server{
+		// Allow connections from any origin, including non-browser clients
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(conn *websocket.Conn) {
+			defer conn.Close()
+
+			sub := s.stream.Subscribe(filter)
+			defer s.stream.Unsubscribe(sub)
+
+			// Incoming messages are ignored, reading only detects the closed connection
+			closed := make(chan bool)
+			go func() {
+				var msg string
+				for websocket.Message.Receive(conn, &msg) == nil {
+				}
+				close(closed)
+			}()
+
+			for {
+				select {
+				case msg := <-sub.messages:
+					if err := websocket.JSON.Send(conn, msg); err != nil {
+						return
+					}
+				case <-closed:
+					return
+				}
+			}
+		},
+	}
+
+	wsServer.ServeHTTP(c.Writer, c.Request)
+}
+
+// GetStreamSSE streams new blocks, transactions and events as server-sent events
+func (s Server) GetStreamSSE(c *gin.Context) {
+	filter := streamFilter{}
+	if err := c.BindQuery(&filter); err != nil {
+		badRequest(c, err)
+		return
+	}
+	if err := filter.Validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	sub := s.stream.Subscribe(filter)
+	defer s.stream.Unsubscribe(sub)
+
+	ping := time.NewTicker(streamPingInterval)
+	defer ping.Stop()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case msg := <-sub.messages:
+			c.SSEvent(msg.Type, msg.Data)
+			return true
+		case <-ping.C:
+			c.SSEvent("ping", time.Now().Unix())
+			return true
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
@@ -0,0 +1,186 @@
+package server
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/figment-networks/near-indexer/model"
+	"github.com/figment-networks/near-indexer/prices"
+	"github.com/figment-networks/near-indexer/store"
+)
+
+const (
+	taxReportLayoutGeneric      = "generic"
+	taxReportLayoutKoinly       = "koinly"
+	taxReportLayoutCoinTracking = "cointracking"
+
+	nearSymbol = "NEAR"
+)
+
+// taxReportRow contains a single delegator reward entry of the tax report
+type taxReportRow struct {
+	Time        time.Time
+	Epoch       string
+	ValidatorID string
+	AmountYocto string
+	AmountNEAR  string
+	Price       string
+	Value       string
+	Currency    string
+}
+
+// taxReportLayout defines the CSV format expected by a tax tool
+type taxReportLayout struct {
+	header []string
+	row    func(r taxReportRow) []string
+}
+
+var taxReportLayouts = map[string]taxReportLayout{
+	taxReportLayoutGeneric: {
+		header: []string{"date", "epoch", "validator", "amount_yocto", "amount_near", "price", "value", "currency"},
+		row: func(r taxReportRow) []string {
+			return []string{
+				r.Time.UTC().Format(time.RFC3339),
+				r.Epoch,
+				r.ValidatorID,
+				r.AmountYocto,
+				r.AmountNEAR,
+				r.Price,
+				r.Value,
+				r.Currency,
+			}
+		},
+	},
+
+	// Koinly universal import format
+	taxReportLayoutKoinly: {
+		header: []string{
+			"Date", "Sent Amount", "Sent Currency", "Received Amount", "Received Currency",
+			"Fee Amount", "Fee Currency", "Net Worth Amount", "Net Worth Currency",
+			"Label", "Description", "TxHash",
+		},
+		row: func(r taxReportRow) []string {
+			netWorthCurrency := ""
+			if r.Value != "" {
+				netWorthCurrency = r.Currency
+			}
+
+			return []string{
+				r.Time.UTC().Format("2006-01-02 15:04:05 UTC"),
+				"",
+				"",
+				r.AmountNEAR,
+				nearSymbol,
+				"",
+				"",
+				r.Value,
+				netWorthCurrency,
+				"staking",
+				fmt.Sprintf("Staking reward from %s for epoch %s", r.ValidatorID, r.Epoch),
+				"",
+			}
+		},
+	},
+
+	// CoinTracking CSV import format
+	taxReportLayoutCoinTracking: {
+		header: []string{
+			"Type", "Buy Amount", "Buy Currency", "Sell Amount", "Sell Currency",
+			"Fee", "Fee Currency", "Exchange", "Trade-Group", "Comment", "Date",
+			"Tx-ID", "Buy Value in Account Currency", "Sell Value in Account Currency",
+		},
+		row: func(r taxReportRow) []string {
+			return []string{
+				"Staking",
+				r.AmountNEAR,
+				nearSymbol,
+				"",
+				"",
+				"",
+				"",
+				r.ValidatorID,
+				"Staking",
+				"Epoch " + r.Epoch,
+				r.Time.UTC().Format("2006-01-02 15:04:05"),
+				"",
+				r.Value,
+				"",
+			}
+		},
+	},
+}
+
+// GetDelegatorTaxReport streams delegator rewards in a CSV layout of a tax tool
+func (s Server) GetDelegatorTaxReport(c *gin.Context) {
+	params := taxReportParams{}
+	if err := c.BindQuery(&params); err != nil {
+		badRequest(c, err)
+		return
+	}
+	if err := params.Validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	search := store.DelegatorEpochsSearch{
+		AccountID:   c.Param("id"),
+		ValidatorID: params.ValidatorID,
+		StartDate:   params.StartDate,
+		EndDate:     params.EndDate,
+	}
+	if err := search.Validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	layout := taxReportLayouts[params.Layout]
+
+	w, err := newExportWriter(c, exportFormatCSV, "tax_report_"+params.Layout, layout.header)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	s.streamExport(w, func() error {
+		return s.db.Delegators.ExportDelegatorEpochs(search, func(de *model.DelegatorEpoch) error {
+			if de.Reward.Int == nil || de.Reward.Sign() <= 0 {
+				return nil
+			}
+
+			row := taxReportRow{
+				Time:        de.DistributedAtTime,
+				Epoch:       de.Epoch,
+				ValidatorID: de.ValidatorID,
+				AmountYocto: de.Reward.String(),
+				AmountNEAR:  de.Reward.NEAR(),
+				Currency:    params.Currency,
+			}
+
+			if s.prices != nil {
+				price, err := s.prices.Price(params.Currency, de.DistributedAtTime)
+				switch err {
+				case nil:
+					row.Price = strconv.FormatFloat(price, 'f', -1, 64)
+					row.Value = fiatValue(de.Reward.Int, price)
+				case prices.ErrPriceNotFound:
+				default:
+					return err
+				}
+			}
+
+			return w.Write(de, layout.row(row))
+		})
+	})
+}
+
+// fiatValue returns the fiat value of a yocto amount with 2 decimal places
+func fiatValue(yocto *big.Int, price float64) string {
+	value := new(big.Float).SetInt(yocto)
+	value.Quo(value, big.NewFloat(1e24))
+	value.Mul(value, big.NewFloat(price))
+	return value.Text('f', 2)
+}
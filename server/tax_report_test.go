@@ -0,0 +1,93 @@
+package server
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFiatValue(t *testing.T) {
+	yocto, _ := new(big.Int).SetString("12500000000000000000000000", 10)
+
+	assert.Equal(t, "15.38", fiatValue(yocto, 1.23))
+	assert.Equal(t, "0.00", fiatValue(big.NewInt(1), 1.23))
+	assert.Equal(t, "0.00", fiatValue(yocto, 0))
+}
+
+func TestTaxReportLayouts(t *testing.T) {
+	row := taxReportRow{
+		Time:        time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC),
+		Epoch:       "epoch1",
+		ValidatorID: "pool.near",
+		AmountYocto: "12500000000000000000000000",
+		AmountNEAR:  "12.5",
+		Price:       "1.23",
+		Value:       "15.38",
+		Currency:    "USD",
+	}
+
+	examples := []struct {
+		layout string
+		header []string
+		row    []string
+	}{
+		{
+			layout: taxReportLayoutGeneric,
+			header: []string{"date", "epoch", "validator", "amount_yocto", "amount_near", "price", "value", "currency"},
+			row:    []string{"2021-01-02T15:04:05Z", "epoch1", "pool.near", "12500000000000000000000000", "12.5", "1.23", "15.38", "USD"},
+		},
+		{
+			layout: taxReportLayoutKoinly,
+			header: []string{
+				"Date", "Sent Amount", "Sent Currency", "Received Amount", "Received Currency",
+				"Fee Amount", "Fee Currency", "Net Worth Amount", "Net Worth Currency",
+				"Label", "Description", "TxHash",
+			},
+			row: []string{
+				"2021-01-02 15:04:05 UTC", "", "", "12.5", "NEAR", "", "", "15.38", "USD",
+				"staking", "Staking reward from pool.near for epoch epoch1", "",
+			},
+		},
+		{
+			layout: taxReportLayoutCoinTracking,
+			header: []string{
+				"Type", "Buy Amount", "Buy Currency", "Sell Amount", "Sell Currency",
+				"Fee", "Fee Currency", "Exchange", "Trade-Group", "Comment", "Date",
+				"Tx-ID", "Buy Value in Account Currency", "Sell Value in Account Currency",
+			},
+			row: []string{
+				"Staking", "12.5", "NEAR", "", "", "", "", "pool.near", "Staking", "Epoch epoch1",
+				"2021-01-02 15:04:05", "", "15.38", "",
+			},
+		},
+	}
+
+	for _, ex := range examples {
+		layout, ok := taxReportLayouts[ex.layout]
+		assert.True(t, ok, ex.layout)
+		assert.Equal(t, ex.header, layout.header, ex.layout)
+		assert.Equal(t, ex.row, layout.row(row), ex.layout)
+		assert.Len(t, layout.row(row), len(layout.header), ex.layout)
+	}
+}
+
+func TestTaxReportLayoutsWithoutPrice(t *testing.T) {
+	row := taxReportRow{
+		Time:        time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC),
+		Epoch:       "epoch1",
+		ValidatorID: "pool.near",
+		AmountYocto: "12500000000000000000000000",
+		AmountNEAR:  "12.5",
+		Currency:    "USD",
+	}
+
+	koinly := taxReportLayouts[taxReportLayoutKoinly].row(row)
+	assert.Equal(t, "", koinly[7])
+	assert.Equal(t, "", koinly[8])
+
+	generic := taxReportLayouts[taxReportLayoutGeneric].row(row)
+	assert.Equal(t, "", generic[5])
+	assert.Equal(t, "", generic[6])
+}
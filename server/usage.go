@@ -0,0 +1,239 @@
+package server
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/figment-networks/near-indexer/model"
+)
+
+const (
+	usageFlushInterval = 10 * time.Second
+)
+
+// usageTracker counts API key requests in memory and periodically flushes the
+// counters into the database. Counters are shared between server instances
+// through the database: every flush reloads the daily total of the key, and idle
+// counters are dropped to be reloaded on the next use, so quotas are enforced
+// across all servers within the flush interval.
+type usageTracker struct {
+	db  usageStore
+	log *logrus.Logger
+
+	mu       sync.Mutex
+	counters map[usageKey]*usageCounter
+	stats    usageStats
+}
+
+// usageStore persists the daily usage counters of API keys
+type usageStore interface {
+	DailyRequests(id int64, day time.Time) (int64, error)
+	IncrementUsage(id int64, day time.Time, requests int64, rejected int64, lastUsed time.Time) (int64, error)
+}
+
+type usageKey struct {
+	id  int64
+	day time.Time
+}
+
+type usageCounter struct {
+	stored          int64 // requests already recorded in the database
+	pending         int64 // requests not yet flushed
+	pendingRejected int64 // rejected requests not yet flushed
+	lastUsed        time.Time
+}
+
+// usageStats contains request counters of the server process
+type usageStats struct {
+	StartedAt     time.Time `json:"started_at"`
+	Requests      int64     `json:"requests"`
+	Anonymous     int64     `json:"anonymous"`
+	Unauthorized  int64     `json:"unauthorized"`
+	RateLimited   int64     `json:"rate_limited"`
+	QuotaExceeded int64     `json:"quota_exceeded"`
+}
+
+func newUsageTracker(db usageStore, logger *logrus.Logger) *usageTracker {
+	return &usageTracker{
+		db:       db,
+		log:      logger,
+		counters: map[usageKey]*usageCounter{},
+		stats:    usageStats{StartedAt: time.Now()},
+	}
+}
+
+// Start flushes the usage counters in background
+func (u *usageTracker) Start() {
+	go func() {
+		for range time.Tick(usageFlushInterval) {
+			if err := u.flush(); err != nil {
+				u.log.WithError(err).Error("api key usage flush failed")
+			}
+		}
+	}()
+}
+
+// track counts a request made with the key and returns false if the key is over
+// its daily quota.
+func (u *usageTracker) track(key *model.APIKey, now time.Time) (int64, bool, error) {
+	counter, err := u.counter(key.ID, usageDay(now))
+	if err != nil {
+		return 0, false, err
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.stats.Requests++
+	counter.lastUsed = now
+
+	used := counter.stored + counter.pending + 1
+	if key.QuotaExceeded(used) {
+		u.stats.QuotaExceeded++
+		counter.pendingRejected++
+		return used - 1, false, nil
+	}
+	counter.pending++
+
+	return used, true, nil
+}
+
+// reject counts a request rejected by the rate limiter
+func (u *usageTracker) reject(key *model.APIKey, now time.Time) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.stats.Requests++
+	u.stats.RateLimited++
+
+	if key == nil {
+		return
+	}
+
+	counter := u.counters[usageKey{key.ID, usageDay(now)}]
+	if counter != nil {
+		counter.pendingRejected++
+		counter.lastUsed = now
+	}
+}
+
+// anonymous counts a request made without an API key
+func (u *usageTracker) anonymous() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.stats.Requests++
+	u.stats.Anonymous++
+}
+
+// unauthorized counts a request with a missing or invalid API key
+func (u *usageTracker) unauthorized() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.stats.Requests++
+	u.stats.Unauthorized++
+}
+
+// Stats returns the request counters of the server process
+func (u *usageTracker) Stats() usageStats {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return u.stats
+}
+
+// counter returns the key's counter for the day, loading the stored number of
+// requests on first use.
+func (u *usageTracker) counter(id int64, day time.Time) (*usageCounter, error) {
+	key := usageKey{id, day}
+
+	u.mu.Lock()
+	counter := u.counters[key]
+	u.mu.Unlock()
+
+	if counter != nil {
+		return counter, nil
+	}
+
+	stored, err := u.db.DailyRequests(id, day)
+	if err != nil {
+		return nil, err
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if counter = u.counters[key]; counter == nil {
+		counter = &usageCounter{stored: stored, lastUsed: time.Now()}
+		u.counters[key] = counter
+	}
+
+	return counter, nil
+}
+
+// flush writes pending counters into the database and drops counters of past days
+func (u *usageTracker) flush() error {
+	type pendingUsage struct {
+		key      usageKey
+		counter  *usageCounter
+		requests int64
+		rejected int64
+		lastUsed time.Time
+	}
+
+	now := time.Now()
+	today := usageDay(now)
+	pending := []pendingUsage{}
+
+	u.mu.Lock()
+	for key, counter := range u.counters {
+		if counter.pending > 0 || counter.pendingRejected > 0 {
+			pending = append(pending, pendingUsage{key, counter, counter.pending, counter.pendingRejected, counter.lastUsed})
+			counter.stored += counter.pending
+			counter.pending = 0
+			counter.pendingRejected = 0
+		} else if now.Sub(counter.lastUsed) >= usageFlushInterval {
+			// Requests of other servers are picked up when the counter is loaded again
+			delete(u.counters, key)
+		}
+		if key.day.Before(today) {
+			delete(u.counters, key)
+		}
+	}
+	u.mu.Unlock()
+
+	for i, item := range pending {
+		total, err := u.db.IncrementUsage(item.key.id, item.key.day, item.requests, item.rejected, item.lastUsed)
+		if err == nil {
+			// Include the requests flushed by other servers
+			u.mu.Lock()
+			item.counter.stored = total
+			u.mu.Unlock()
+			continue
+		}
+
+		// Put back the counters that were not saved, to retry on the next flush
+		u.mu.Lock()
+		for _, item := range pending[i:] {
+			if _, ok := u.counters[item.key]; !ok {
+				u.counters[item.key] = item.counter
+			}
+			item.counter.stored -= item.requests
+			item.counter.pending += item.requests
+			item.counter.pendingRejected += item.rejected
+		}
+		u.mu.Unlock()
+
+		return err
+	}
+
+	return nil
+}
+
+// usageDay returns the UTC day of the timestamp
+func usageDay(ts time.Time) time.Time {
+	return ts.UTC().Truncate(24 * time.Hour)
+}
@@ -0,0 +1,185 @@
+package server
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/figment-networks/near-indexer/model"
+)
+
+// fixtureUsage keeps the daily usage of API keys in memory
+type fixtureUsage struct {
+	sync.Mutex
+	requests map[int64]int64
+	rejected map[int64]int64
+	failing  map[int64]bool
+}
+
+func newFixtureUsage() *fixtureUsage {
+	return &fixtureUsage{
+		requests: map[int64]int64{},
+		rejected: map[int64]int64{},
+		failing:  map[int64]bool{},
+	}
+}
+
+func (s *fixtureUsage) DailyRequests(id int64, day time.Time) (int64, error) {
+	s.Lock()
+	defer s.Unlock()
+	return s.requests[id], nil
+}
+
+func (s *fixtureUsage) IncrementUsage(id int64, day time.Time, requests int64, rejected int64, lastUsed time.Time) (int64, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.failing[id] {
+		return 0, errors.New("database is unavailable")
+	}
+	s.requests[id] += requests
+	s.rejected[id] += rejected
+	return s.requests[id], nil
+}
+
+func testAPIKey(id int64, quota int64) *model.APIKey {
+	key := &model.APIKey{DailyQuota: quota}
+	key.ID = id
+	return key
+}
+
+func TestUsageTrackerTrack(t *testing.T) {
+	examples := []struct {
+		quota    int64
+		stored   int64
+		requests int
+		used     []int64
+		allowed  []bool
+	}{
+		{quota: 0, stored: 100, requests: 2, used: []int64{101, 102}, allowed: []bool{true, true}},
+		{quota: 3, stored: 0, requests: 4, used: []int64{1, 2, 3, 3}, allowed: []bool{true, true, true, false}},
+		{quota: 3, stored: 2, requests: 3, used: []int64{3, 3, 3}, allowed: []bool{true, false, false}},
+		{quota: 3, stored: 3, requests: 1, used: []int64{3}, allowed: []bool{false}},
+		{quota: 3, stored: 5, requests: 1, used: []int64{5}, allowed: []bool{false}},
+	}
+
+	for _, ex := range examples {
+		db := newFixtureUsage()
+		db.requests[1] = ex.stored
+
+		tracker := newUsageTracker(db, logrus.New())
+		key := testAPIKey(1, ex.quota)
+		now := time.Now()
+
+		for i := 0; i < ex.requests; i++ {
+			used, ok, err := tracker.track(key, now)
+			assert.NoError(t, err)
+			assert.Equal(t, ex.used[i], used, "request %d", i)
+			assert.Equal(t, ex.allowed[i], ok, "request %d", i)
+		}
+
+		rejected := 0
+		for _, ok := range ex.allowed {
+			if !ok {
+				rejected++
+			}
+		}
+
+		stats := tracker.Stats()
+		assert.Equal(t, int64(ex.requests), stats.Requests)
+		assert.Equal(t, int64(rejected), stats.QuotaExceeded)
+
+		// Only allowed requests count against the quota
+		assert.NoError(t, tracker.flush())
+		assert.Equal(t, ex.stored+int64(ex.requests-rejected), db.requests[1])
+		assert.Equal(t, int64(rejected), db.rejected[1])
+	}
+}
+
+func TestUsageTrackerFlush(t *testing.T) {
+	db := newFixtureUsage()
+	tracker := newUsageTracker(db, logrus.New())
+	key := testAPIKey(1, 5)
+	now := time.Now()
+
+	for i := 0; i < 3; i++ {
+		tracker.track(key, now)
+	}
+
+	// Requests made on other servers are picked up on flush
+	db.requests[1] = 1
+	assert.NoError(t, tracker.flush())
+	assert.Equal(t, int64(4), db.requests[1])
+
+	used, ok, _ := tracker.track(key, now)
+	assert.Equal(t, int64(5), used)
+	assert.True(t, ok)
+
+	used, ok, _ = tracker.track(key, now)
+	assert.Equal(t, int64(5), used)
+	assert.False(t, ok)
+}
+
+func TestUsageTrackerFlushFailure(t *testing.T) {
+	db := newFixtureUsage()
+	db.failing[1] = true
+
+	tracker := newUsageTracker(db, logrus.New())
+	first := testAPIKey(1, 3)
+	second := testAPIKey(2, 0)
+	now := time.Now()
+
+	tracker.track(first, now)
+	tracker.track(first, now)
+	tracker.track(second, now)
+
+	assert.Error(t, tracker.flush())
+	assert.Equal(t, int64(0), db.requests[1])
+
+	// Counters that were not saved are put back and still count against the quota
+	used, ok, _ := tracker.track(first, now)
+	assert.Equal(t, int64(3), used)
+	assert.True(t, ok)
+
+	_, ok, _ = tracker.track(first, now)
+	assert.False(t, ok)
+
+	db.failing[1] = false
+	assert.NoError(t, tracker.flush())
+	assert.Equal(t, int64(3), db.requests[1])
+	assert.Equal(t, int64(1), db.rejected[1])
+	assert.Equal(t, int64(1), db.requests[2])
+
+	// Nothing is flushed twice
+	assert.NoError(t, tracker.flush())
+	assert.Equal(t, int64(3), db.requests[1])
+	assert.Equal(t, int64(1), db.requests[2])
+}
+
+func TestUsageTrackerFlushIdle(t *testing.T) {
+	db := newFixtureUsage()
+	tracker := newUsageTracker(db, logrus.New())
+	now := time.Now()
+
+	tracker.track(testAPIKey(1, 0), now.Add(-usageFlushInterval))
+	tracker.track(testAPIKey(2, 0), now.Add(-usageFlushInterval))
+	assert.NoError(t, tracker.flush())
+	assert.Len(t, tracker.counters, 2)
+
+	// Counters without new requests are dropped, to be reloaded on the next use
+	tracker.track(testAPIKey(2, 0), now)
+	assert.NoError(t, tracker.flush())
+	assert.Len(t, tracker.counters, 1)
+
+	// Counters of past days are dropped
+	yesterday := now.Add(-24 * time.Hour)
+	tracker.track(testAPIKey(3, 0), yesterday)
+	assert.Len(t, tracker.counters, 2)
+	assert.NoError(t, tracker.flush())
+	assert.Len(t, tracker.counters, 1)
+	assert.Equal(t, int64(1), db.requests[3])
+}
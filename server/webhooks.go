@@ -0,0 +1,187 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/figment-networks/near-indexer/model"
+	"github.com/figment-networks/near-indexer/store"
+)
+
+// webhookParams contains the webhook attributes submitted by the user
+type webhookParams struct {
+	URL         string   `json:"url"`
+	Secret      string   `json:"secret"`
+	Actions     []string `json:"actions"`
+	ValidatorID string   `json:"validator_id"`
+	Account     string   `json:"account"`
+	Active      *bool    `json:"active"`
+}
+
+// webhookWithSecret renders the webhook along with its signing secret
+type webhookWithSecret struct {
+	*model.Webhook
+	Secret string `json:"secret"`
+}
+
+// assign copies the submitted attributes to the webhook
+func (p webhookParams) assign(w *model.Webhook) {
+	w.URL = p.URL
+	w.ValidatorID = p.ValidatorID
+	w.Account = p.Account
+	w.Actions = p.Actions
+	if w.Actions == nil {
+		w.Actions = []string{}
+	}
+	if p.Secret != "" {
+		w.Secret = p.Secret
+	}
+	if p.Active != nil {
+		w.Active = *p.Active
+	}
+}
+
+// findWebhook returns a webhook for the ID provided in the path.
+// Only webhooks registered with the request API key could be accessed.
+func (s Server) findWebhook(c *gin.Context) (*model.Webhook, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return nil, store.ErrNotFound
+	}
+	return s.db.Webhooks.FindOwnedByID(requestAPIKey(c).ID, id)
+}
+
+// GetWebhooks renders all webhooks registered with the request API key
+func (s Server) GetWebhooks(c *gin.Context) {
+	webhooks, err := s.db.Webhooks.FindByOwner(requestAPIKey(c).ID)
+	if shouldReturn(c, err) {
+		return
+	}
+	jsonOk(c, webhooks)
+}
+
+// GetWebhook renders a webhook by ID
+func (s Server) GetWebhook(c *gin.Context) {
+	webhook, err := s.findWebhook(c)
+	if shouldReturn(c, err) {
+		return
+	}
+	jsonOk(c, webhook)
+}
+
+// CreateWebhook registers a new webhook
+func (s Server) CreateWebhook(c *gin.Context) {
+	params := webhookParams{}
+	if err := c.BindJSON(&params); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	ownerID := requestAPIKey(c).ID
+
+	webhook := &model.Webhook{APIKeyID: &ownerID, Active: true}
+	params.assign(webhook)
+
+	if webhook.Secret == "" {
+		secret, err := generateWebhookSecret()
+		if err != nil {
+			serverError(c, err)
+			return
+		}
+		webhook.Secret = secret
+	}
+
+	if err := webhook.Validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	if err := s.db.Webhooks.Create(webhook); err != nil {
+		serverError(c, err)
+		return
+	}
+
+	// Secret is only rendered once, it must be stored by the user to verify payloads
+	jsonOk(c, webhookWithSecret{Webhook: webhook, Secret: webhook.Secret})
+}
+
+// UpdateWebhook updates an existing webhook
+func (s Server) UpdateWebhook(c *gin.Context) {
+	webhook, err := s.findWebhook(c)
+	if shouldReturn(c, err) {
+		return
+	}
+
+	params := webhookParams{}
+	if err := c.BindJSON(&params); err != nil {
+		badRequest(c, err)
+		return
+	}
+	params.assign(webhook)
+
+	if err := webhook.Validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	if err := s.db.Webhooks.Update(webhook); err != nil {
+		serverError(c, err)
+		return
+	}
+
+	jsonOk(c, webhook)
+}
+
+// DeleteWebhook removes a webhook along with its deliveries
+func (s Server) DeleteWebhook(c *gin.Context) {
+	webhook, err := s.findWebhook(c)
+	if shouldReturn(c, err) {
+		return
+	}
+
+	if err := s.db.Webhooks.Delete(webhook.ID); err != nil {
+		serverError(c, err)
+		return
+	}
+
+	jsonOk(c, webhook)
+}
+
+// GetWebhookDeliveries renders the delivery log of a webhook
+func (s Server) GetWebhookDeliveries(c *gin.Context) {
+	webhook, err := s.findWebhook(c)
+	if shouldReturn(c, err) {
+		return
+	}
+
+	search := store.WebhookDeliveriesSearch{}
+	if err := c.BindQuery(&search); err != nil {
+		badRequest(c, err)
+		return
+	}
+	search.WebhookID = webhook.ID
+
+	if err := search.Validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	deliveries, err := s.db.Webhooks.Deliveries(search)
+	if shouldReturn(c, err) {
+		return
+	}
+
+	jsonOk(c, deliveries)
+}
+
+// generateWebhookSecret returns a random secret used to sign webhook payloads
+func generateWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
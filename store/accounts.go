@@ -1,6 +1,7 @@
 package store
 
 import (
+	"strings"
 	"time"
 
 	"github.com/figment-networks/indexing-engine/store/bulk"
@@ -17,10 +18,61 @@ type AccountsStore struct {
 // FindByName returns an account for a name
 func (s AccountsStore) FindByName(name string) (*model.Account, error) {
 	result := &model.Account{}
-	err := findBy(s.db, result, "name", name)
+	err := findBy(s.reader(), result, "name", name)
 	return result, checkErr(err)
 }
 
+// FindByNames returns accounts for the given names
+func (s AccountsStore) FindByNames(names []string) ([]model.Account, error) {
+	result := []model.Account{}
+	err := s.reader().Where("name IN (?)", names).Find(&result).Error
+	return result, err
+}
+
+// SearchByPrefix returns accounts with names starting with the prefix
+func (s AccountsStore) SearchByPrefix(prefix string, limit uint) ([]model.Account, error) {
+	result := []model.Account{}
+
+	err := s.reader().
+		Where("name LIKE ?", likePrefix(prefix)).
+		Order("name ASC").
+		Limit(limit).
+		Find(&result).
+		Error
+
+	return result, err
+}
+
+// ActiveByInterval returns active accounts counts for a time interval
+func (s AccountsStore) ActiveByInterval(from time.Time, to time.Time, timeInterval model.TimeInterval) ([]model.ActiveAccountsSummary, error) {
+	bucket, err := intervalBucket(timeInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	slt := "to_char(time, $INTERVAL) AS interval, signers_count, receivers_count, accounts_count, new_accounts_count"
+	slt = strings.Replace(slt, "$INTERVAL", "'"+timeInterval.String()+"'", -1)
+
+	scope := s.reader().
+		Select(slt).
+		Table("active_accounts").
+		Where("bucket = ?", bucket)
+
+	if !from.IsZero() {
+		scope = scope.Where("time >= ?", from)
+	}
+	if !to.IsZero() {
+		scope = scope.Where("time < ?", to)
+	}
+
+	res := []model.ActiveAccountsSummary{}
+	if err := scope.Order("time ASC").Find(&res).Error; err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 // Import imports new records and updates existing ones
 func (s AccountsStore) Import(records []model.Account) error {
 	t := time.Now()
@@ -0,0 +1,225 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"github.com/figment-networks/near-indexer/model"
+)
+
+const (
+	// activityStakingCondition matches transactions with staking pool calls or stake actions
+	activityStakingCondition = `(
+  CASE WHEN JSONB_TYPEOF(transactions.actions) = 'array' THEN (
+    transactions.actions @> '[{"type": "Stake"}]'
+    OR EXISTS (
+      SELECT 1 FROM JSONB_ARRAY_ELEMENTS(transactions.actions) AS action
+      WHERE
+        action->>'type' = 'FunctionCall'
+        AND action->'data'->>'method_name' IN ('deposit_and_stake', 'stake', 'stake_all', 'unstake', 'unstake_all', 'withdraw_all')
+    )
+  ) ELSE FALSE END
+)`
+)
+
+// activitySource describes the table of a single activity type
+type activitySource struct {
+	index        int64
+	table        string
+	heightColumn string
+	timeColumn   string
+	condition    string
+}
+
+var (
+	// activitySources contains sources of all activity types, @account is replaced with the account name.
+	// Activity IDs combine the record ID with the type index, to keep them unique across tables
+	// while preserving the order of records within a table.
+	activitySources = map[string]activitySource{
+		model.ActivityTransactionSigned: {
+			index:        0,
+			table:        "transactions",
+			heightColumn: "height",
+			timeColumn:   "time",
+			condition:    "sender = @account AND NOT " + activityStakingCondition,
+		},
+		model.ActivityTransactionReceived: {
+			index:        1,
+			table:        "transactions",
+			heightColumn: "height",
+			timeColumn:   "time",
+			condition:    "receiver = @account AND sender <> @account",
+		},
+		model.ActivityReceipt: {
+			index:        2,
+			table:        "receipts",
+			heightColumn: "height",
+			timeColumn:   "time",
+			condition:    "receiver = @account",
+		},
+		model.ActivityStaking: {
+			index:        3,
+			table:        "transactions",
+			heightColumn: "height",
+			timeColumn:   "time",
+			condition:    "sender = @account AND " + activityStakingCondition,
+		},
+		model.ActivityReward: {
+			index:        4,
+			table:        "delegator_epochs",
+			heightColumn: "distributed_at_height",
+			timeColumn:   "distributed_at_time",
+			condition:    "account_id = @account",
+		},
+		model.ActivityValidatorEvent: {
+			index:        5,
+			table:        "events",
+			heightColumn: "block_height",
+			timeColumn:   "block_time",
+			condition:    "item_id = @account AND item_type = 'validator'",
+		},
+	}
+)
+
+// activityTypeCount is the multiplier of record IDs in activity IDs
+const activityTypeCount = 8
+
+// ActivitySearch contains the account activity feed filters
+type ActivitySearch struct {
+	Pagination
+
+	Account string `form:"-"`
+	Types   string `form:"types"`
+
+	types []string
+}
+
+// AccountActivityStore builds the account activity feed
+type AccountActivityStore struct {
+	baseStore
+}
+
+func (s *ActivitySearch) Validate() error {
+	if s.Account == "" {
+		return errors.New("account is required")
+	}
+
+	s.types = model.ActivityTypes
+	if s.Types != "" {
+		s.types = strings.Split(s.Types, ",")
+	}
+	for _, t := range s.types {
+		if _, ok := activitySources[t]; !ok {
+			return errors.New("invalid activity type: " + t)
+		}
+	}
+
+	return s.Pagination.Validate()
+}
+
+// where returns the condition of the activity type for the searched account
+func (s ActivitySearch) where(src activitySource) string {
+	return strings.Replace(src.condition, "@account", pq.QuoteLiteral(s.Account), -1)
+}
+
+// page returns the query of a single activity type, limited to the records
+// that could appear on the requested page. Each type is paged on its own
+// (height, id) index, the cursor ID is converted back to the record ID.
+// The order uses table columns, the output id column is the activity ID.
+func (s ActivitySearch) page(activityType string) string {
+	src := activitySources[activityType]
+	where := s.where(src)
+	dir := "DESC"
+	limit := s.Page * s.Limit
+
+	if s.cursor != nil {
+		limit = s.Limit
+
+		op, id := "<", ceilDiv(s.cursor.ID-src.index, activityTypeCount)
+		if s.cursor.Prev {
+			op, id = ">", floorDiv(s.cursor.ID-src.index, activityTypeCount)
+			dir = "ASC"
+		}
+		where = fmt.Sprintf("%s AND (%s, id) %s (%d, %d)", where, src.heightColumn, op, s.cursor.Height, id)
+	}
+
+	return fmt.Sprintf(
+		"(SELECT id::BIGINT * %[1]d + %[2]d AS id, '%[3]s' AS type, %[4]s AS height, %[5]s AS time, TO_JSONB(%[6]s) AS data\n"+
+			"FROM %[6]s\nWHERE %[7]s\nORDER BY %[6]s.%[4]s %[8]s, %[6]s.id %[8]s\nLIMIT %[9]d)",
+		activityTypeCount, src.index, activityType, src.heightColumn, src.timeColumn, src.table, where, dir, limit,
+	)
+}
+
+// query returns the union of the pages of all requested activity types
+func (s ActivitySearch) query() string {
+	parts := make([]string, len(s.types))
+	for i, t := range s.types {
+		parts[i] = s.page(t)
+	}
+	return strings.Join(parts, "\nUNION ALL\n")
+}
+
+// countQuery returns the total number of records of all requested activity types
+func (s ActivitySearch) countQuery() string {
+	parts := make([]string, len(s.types))
+	for i, t := range s.types {
+		src := activitySources[t]
+		parts[i] = fmt.Sprintf("SELECT COUNT(1) AS count FROM %s WHERE %s", src.table, s.where(src))
+	}
+	return "SELECT COALESCE(SUM(count), 0) FROM (" + strings.Join(parts, "\nUNION ALL\n") + ") AS counts"
+}
+
+// Search returns the account activity, most recent first
+func (s AccountActivityStore) Search(search ActivitySearch) (*PaginatedResult, error) {
+	if err := search.Validate(); err != nil {
+		return nil, err
+	}
+
+	var count uint
+	if !search.SkipCount {
+		if err := s.reader().Raw(search.countQuery()).Row().Scan(&count); err != nil {
+			return nil, err
+		}
+	}
+
+	dir := "DESC"
+	if search.cursor != nil && search.cursor.Prev {
+		dir = "ASC"
+	}
+
+	scope := s.reader().
+		Table("(" + search.query() + ") AS activity").
+		Order(fmt.Sprintf("height %[1]s, id %[1]s", dir)).
+		Limit(search.Limit)
+
+	if search.cursor == nil {
+		scope = scope.Offset((search.Page - 1) * search.Limit)
+	}
+
+	records := []model.Activity{}
+	if err := scope.Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	result := search.paginateResult(records, count, func(i int) Cursor {
+		return Cursor{Height: uint64(records[i].Height), ID: records[i].ID}
+	})
+
+	return result, nil
+}
+
+// floorDiv returns a / b rounded towards negative infinity
+func floorDiv(a, b int64) int64 {
+	if a%b != 0 && (a < 0) != (b < 0) {
+		return a/b - 1
+	}
+	return a / b
+}
+
+// ceilDiv returns a / b rounded towards positive infinity
+func ceilDiv(a, b int64) int64 {
+	return -floorDiv(-a, b)
+}
@@ -0,0 +1,85 @@
+package store
+
+import (
+	"time"
+
+	"github.com/figment-networks/near-indexer/model"
+	"github.com/figment-networks/near-indexer/store/queries"
+)
+
+// APIKeysStore manages API keys and their usage counters
+type APIKeysStore struct {
+	baseStore
+}
+
+// All returns all API keys
+func (s APIKeysStore) All() ([]model.APIKey, error) {
+	result := []model.APIKey{}
+
+	err := s.db.
+		Order("id ASC").
+		Find(&result).
+		Error
+
+	return result, err
+}
+
+// FindByID returns an API key for a given ID
+func (s APIKeysStore) FindByID(id int64) (*model.APIKey, error) {
+	result := &model.APIKey{}
+	err := findBy(s.db, result, "id", id)
+	return result, checkErr(err)
+}
+
+// FindByKey returns an API key matching the plain text key
+func (s APIKeysStore) FindByKey(key string) (*model.APIKey, error) {
+	result := &model.APIKey{}
+	err := findBy(s.db, result, "key_hash", model.HashAPIKey(key))
+	return result, checkErr(err)
+}
+
+// Delete removes the API key along with its usage counters
+func (s APIKeysStore) Delete(id int64) error {
+	return s.db.Delete(&model.APIKey{}, "id = ?", id).Error
+}
+
+// IncrementUsage adds the number of requests to the key's daily counter and
+// returns the total number of requests made with the key on that day
+func (s APIKeysStore) IncrementUsage(id int64, day time.Time, requests int64, rejected int64, lastUsed time.Time) (int64, error) {
+	var total int64
+	err := s.db.Raw(queries.KeyUsageIncrement, id, formatDay(day), requests, rejected, lastUsed).Row().Scan(&total)
+	return total, err
+}
+
+// DailyRequests returns the number of requests made with the key on a given day
+func (s APIKeysStore) DailyRequests(id int64, day time.Time) (int64, error) {
+	usage := model.APIKeyUsage{}
+
+	err := s.db.
+		Where("api_key_id = ? AND day = ?", id, formatDay(day)).
+		Take(&usage).
+		Error
+
+	if err = checkErr(err); err == ErrNotFound {
+		return 0, nil
+	}
+	return usage.Requests, err
+}
+
+// Usage returns daily usage counters of all keys since a given day
+func (s APIKeysStore) Usage(since time.Time) ([]model.APIKeyUsage, error) {
+	result := []model.APIKeyUsage{}
+
+	err := s.db.
+		Where("day >= ?", formatDay(since)).
+		Order("day DESC, api_key_id ASC").
+		Find(&result).
+		Error
+
+	return result, err
+}
+
+// formatDay returns the date of the day, to avoid timezone conversions of DATE columns
+func formatDay(day time.Time) string {
+	return day.Format("2006-01-02")
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/figment-networks/indexing-engine/store/bulk"
 	"github.com/jinzhu/gorm"
@@ -11,12 +12,15 @@ import (
 
 var (
 	ErrNotFound = errors.New("record not found")
+
+	likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
 )
 
 // baseStore implements generic store operations
 type baseStore struct {
-	db    *gorm.DB
-	model interface{}
+	db       *gorm.DB
+	model    interface{}
+	replicas *replicaSet
 }
 
 // Conn returns an underlying database connection
@@ -24,6 +28,14 @@ func (s baseStore) Conn() *sql.DB {
 	return s.db.DB()
 }
 
+// reader returns a read replica connection if available, or the primary connection
+func (s baseStore) reader() *gorm.DB {
+	if s.replicas == nil {
+		return s.db
+	}
+	return s.replicas.reader()
+}
+
 // Create creates a new record. Must pass a pointer.
 func (s baseStore) Create(record interface{}) error {
 	err := s.db.Create(record).Error
@@ -52,8 +64,8 @@ func (s baseStore) bulkImport(query string, rows int, fn bulk.RowFunc) error {
 }
 
 // scoped returns a scoped store
-func scoped(conn *gorm.DB, m interface{}) baseStore {
-	return baseStore{conn, m}
+func scoped(conn *gorm.DB, replicas *replicaSet, m interface{}) baseStore {
+	return baseStore{conn, m, replicas}
 }
 
 // isNotFound reports missing record status
@@ -77,3 +89,8 @@ func checkErr(err error) error {
 	}
 	return err
 }
+
+// likePrefix returns a LIKE pattern matching values starting with the prefix
+func likePrefix(prefix string) string {
+	return likeEscaper.Replace(prefix) + "%"
+}
@@ -0,0 +1,65 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+)
+
+// batchQuery combines the scopes of every key into a single UNION ALL query.
+// Each branch keeps its own filters, order and limit, so it is still served by
+// the key index, and returns its key in the batch_key column.
+func batchQuery(db *gorm.DB, keys []string, columns string, scope func(key string) *gorm.DB, order string) *gorm.DB {
+	parts := make([]string, len(keys))
+	args := make([]interface{}, len(keys))
+
+	for i, key := range keys {
+		parts[i] = "(?)"
+		args[i] = scope(key).Select("?::TEXT AS batch_key, "+columns, key).QueryExpr()
+	}
+
+	query := fmt.Sprintf("SELECT * FROM (%s) AS batch ORDER BY %s", strings.Join(parts, " UNION ALL "), order)
+	return db.Raw(query, args...)
+}
+
+// paginateBatch applies the pagination to the scope of every key and returns
+// all pages in a single query, ordered the same way as each of the pages
+func (p Pagination) paginateBatch(db *gorm.DB, keys []string, heightColumn string, scope func(key string) *gorm.DB) *gorm.DB {
+	dir := "DESC"
+	if p.cursor != nil && p.cursor.Prev {
+		dir = "ASC"
+	}
+
+	order := fmt.Sprintf("%[1]s %[2]s, id %[2]s", heightColumn, dir)
+	if heightColumn == "id" {
+		order = "id " + dir
+	}
+
+	return batchQuery(db, keys, "*", func(key string) *gorm.DB {
+		return p.paginate(scope(key), heightColumn)
+	}, order)
+}
+
+// countBatch returns the number of records in the scope of every key,
+// or nothing when counts are skipped
+func (p Pagination) countBatch(db *gorm.DB, keys []string, scope func(key string) *gorm.DB) (map[string]uint, error) {
+	if p.SkipCount {
+		return nil, nil
+	}
+
+	rows := []struct {
+		BatchKey string
+		Count    uint
+	}{}
+
+	if err := batchQuery(db, keys, "COUNT(1) AS count", scope, "batch_key").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]uint, len(rows))
+	for _, row := range rows {
+		counts[row.BatchKey] = row.Count
+	}
+	return counts, nil
+}
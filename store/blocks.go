@@ -5,6 +5,7 @@ import (
 
 	"github.com/figment-networks/indexing-engine/store/bulk"
 	"github.com/figment-networks/indexing-engine/store/jsonquery"
+	"github.com/jinzhu/gorm"
 
 	"github.com/figment-networks/near-indexer/model"
 	"github.com/figment-networks/near-indexer/store/queries"
@@ -27,7 +28,7 @@ func (s BlocksStore) CreateIfNotExists(block *model.Block) error {
 // FindBy returns a block for a matching attribute
 func (s BlocksStore) FindBy(key string, value interface{}) (*model.Block, error) {
 	result := &model.Block{}
-	err := findBy(s.db, result, key, value)
+	err := findBy(s.reader(), result, key, value)
 	return result, checkErr(err)
 }
 
@@ -41,11 +42,25 @@ func (s BlocksStore) FindByHeight(height uint64) (*model.Block, error) {
 	return s.FindBy("id", height)
 }
 
+// FindByHeights returns blocks for the given heights
+func (s BlocksStore) FindByHeights(heights []uint64) ([]model.Block, error) {
+	result := []model.Block{}
+	err := s.reader().Where("id IN (?)", heights).Find(&result).Error
+	return result, err
+}
+
+// FindByHashes returns blocks for the given hashes
+func (s BlocksStore) FindByHashes(hashes []string) ([]model.Block, error) {
+	result := []model.Block{}
+	err := s.reader().Where("hash IN (?)", hashes).Find(&result).Error
+	return result, err
+}
+
 // FindPrevious returns a block prior to the given height
 func (s BlocksStore) FindPrevious(height uint64) (*model.Block, error) {
 	block := &model.Block{}
 
-	err := s.db.
+	err := s.reader().
 		Order("id DESC").
 		Limit(1).
 		Find(block, "id < ?", height).
@@ -54,11 +69,37 @@ func (s BlocksStore) FindPrevious(height uint64) (*model.Block, error) {
 	return block, checkErr(err)
 }
 
+// FindNext returns a block following the given height
+func (s BlocksStore) FindNext(height uint64) (*model.Block, error) {
+	block := &model.Block{}
+
+	err := s.reader().
+		Order("id ASC").
+		Limit(1).
+		Find(block, "id > ?", height).
+		Error
+
+	return block, checkErr(err)
+}
+
+// First returns the first indexed block
+func (s BlocksStore) First() (*model.Block, error) {
+	block := &model.Block{}
+
+	err := s.reader().
+		Order("id ASC").
+		Limit(1).
+		Find(&block).
+		Error
+
+	return block, checkErr(err)
+}
+
 // Last returns the last indexed block
 func (s BlocksStore) Last() (*model.Block, error) {
 	block := &model.Block{}
 
-	err := s.db.
+	err := s.reader().
 		Order("id DESC").
 		Limit(1).
 		Find(&block).
@@ -71,7 +112,7 @@ func (s BlocksStore) Last() (*model.Block, error) {
 func (s BlocksStore) LastInEpoch(epoch string) (*model.Block, error) {
 	block := &model.Block{}
 
-	err := s.db.
+	err := s.reader().
 		Order("id DESC").
 		Limit(1).
 		Take(&block, "epoch = ?", epoch).
@@ -81,26 +122,122 @@ func (s BlocksStore) LastInEpoch(epoch string) (*model.Block, error) {
 }
 
 // Search returns matching blocks
-func (s BlocksStore) Search() ([]model.Block, error) {
-	result := []model.Block{}
+func (s BlocksStore) Search(search BlocksSearch) (*PaginatedResult, error) {
+	if err := search.Validate(); err != nil {
+		return nil, err
+	}
 
-	err := s.db.
-		Order("id DESC").
-		Limit(50).
-		Find(&result).
+	scope := s.reader().Model(&model.Block{})
+
+	if search.Producer != "" {
+		scope = scope.Where("producer = ?", search.Producer)
+	}
+	if search.Epoch != "" {
+		scope = scope.Where("epoch = ?", search.Epoch)
+	}
+	if search.MinHeight > 0 {
+		scope = scope.Where("id >= ?", search.MinHeight)
+	}
+	if search.MaxHeight > 0 {
+		scope = scope.Where("id <= ?", search.MaxHeight)
+	}
+	if search.MinTransactions > 0 {
+		scope = scope.Where("transactions_count >= ?", search.MinTransactions)
+	}
+	if search.startTime != nil {
+		scope = scope.Where("time >= ?", search.startTime)
+	}
+	if search.endTime != nil {
+		scope = scope.Where("time <= ?", search.endTime)
+	}
+
+	// Counting all blocks requires a full table scan, so it's only done for filtered searches
+	var count uint
+	if !search.SkipCount && search.hasFilters() {
+		if err := scope.Count(&count).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	blocks := []model.Block{}
+
+	err := search.
+		paginate(scope, "id").
+		Find(&blocks).
 		Error
 
-	return result, err
+	if err != nil {
+		return nil, err
+	}
+
+	result := search.paginateResult(blocks, count, func(i int) Cursor {
+		return Cursor{Height: uint64(blocks[i].ID), ID: int64(blocks[i].ID)}
+	})
+
+	return result, nil
+}
+
+// SearchByProducers returns a page of blocks for each of the producers in a single query
+func (s BlocksStore) SearchByProducers(producers []string, pagination Pagination) (map[string]*PaginatedResult, error) {
+	if err := pagination.Validate(); err != nil {
+		return nil, err
+	}
+	if len(producers) == 0 {
+		return map[string]*PaginatedResult{}, nil
+	}
+
+	scope := func(producer string) *gorm.DB {
+		return s.reader().Model(&model.Block{}).Where("producer = ?", producer)
+	}
+
+	counts, err := pagination.countBatch(s.reader(), producers, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []struct {
+		BatchKey string
+		model.Block
+	}{}
+
+	if err := pagination.paginateBatch(s.reader(), producers, "id", scope).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	grouped := map[string][]model.Block{}
+	for _, row := range rows {
+		grouped[row.BatchKey] = append(grouped[row.BatchKey], row.Block)
+	}
+
+	result := make(map[string]*PaginatedResult, len(producers))
+	for _, producer := range producers {
+		blocks := append([]model.Block{}, grouped[producer]...)
+		result[producer] = pagination.paginateResult(blocks, counts[producer], func(i int) Cursor {
+			return Cursor{Height: uint64(blocks[i].ID), ID: int64(blocks[i].ID)}
+		})
+	}
+
+	return result, nil
 }
 
 // BlockTimes returns recent blocks averages
 func (s BlocksStore) BlockTimes(limit int64) ([]byte, error) {
-	return jsonquery.MustObject(s.db, queries.BlockTimes, limit)
+	return jsonquery.MustObject(s.reader(), queries.BlockTimes, limit)
 }
 
 // BlockStats returns block stats for a given interval
 func (s BlocksStore) BlockStats(bucket string, limit uint) ([]byte, error) {
-	return jsonquery.MustArray(s.db, queries.BlocksTimeStats, bucket, limit)
+	return jsonquery.MustArray(s.reader(), queries.BlocksTimeStats, bucket, limit)
+}
+
+// BlockStatsRange returns block stats for a given interval and time range
+func (s BlocksStore) BlockStatsRange(bucket string, timeRange TimeRange) ([]byte, error) {
+	return jsonquery.MustArray(s.reader(), queries.BlocksTimeStatsRange, bucket, timeRange.Start, timeRange.End)
+}
+
+// GasStats returns gas price and utilization stats for a given interval
+func (s BlocksStore) GasStats(bucket string, limit uint) ([]byte, error) {
+	return jsonquery.MustArray(s.reader(), queries.BlocksGasStats, bucket, limit)
 }
 
 // Import creates block records in batch
@@ -120,6 +257,7 @@ func (s BlocksStore) Import(records []model.Block) error {
 			r.GasLimit,
 			r.GasUsed,
 			r.TotalSupply,
+			r.BalanceBurnt,
 			r.ChunksCount,
 			r.TransactionsCount,
 			r.ApprovalsCount,
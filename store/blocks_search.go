@@ -0,0 +1,58 @@
+package store
+
+import (
+	"errors"
+	"time"
+)
+
+type BlocksSearch struct {
+	Pagination
+
+	Producer        string `form:"producer"`
+	Epoch           string `form:"epoch"`
+	MinHeight       uint64 `form:"min_height"`
+	MaxHeight       uint64 `form:"max_height"`
+	MinTransactions int    `form:"min_transactions"`
+	StartDate       string `form:"start_date"`
+	EndDate         string `form:"end_date"`
+
+	startTime *time.Time
+	endTime   *time.Time
+}
+
+func (s *BlocksSearch) Validate() error {
+	if err := s.Pagination.Validate(); err != nil {
+		return err
+	}
+
+	if s.MaxHeight > 0 && s.MinHeight > s.MaxHeight {
+		return errors.New("height range is invalid")
+	}
+	if s.MinTransactions < 0 {
+		return errors.New("min transactions is invalid")
+	}
+
+	if t, err := parseTimeFilter(s.StartDate); err == nil {
+		s.startTime = t
+	} else {
+		return errors.New("start time is invalid")
+	}
+	if t, err := parseTimeFilter(s.EndDate); err == nil {
+		s.endTime = t
+	} else {
+		return errors.New("end time is invalid")
+	}
+
+	return nil
+}
+
+// hasFilters returns true if any of the search filters is set
+func (s BlocksSearch) hasFilters() bool {
+	return s.Producer != "" ||
+		s.Epoch != "" ||
+		s.MinHeight > 0 ||
+		s.MaxHeight > 0 ||
+		s.MinTransactions > 0 ||
+		s.startTime != nil ||
+		s.endTime != nil
+}
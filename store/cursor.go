@@ -0,0 +1,147 @@
+package store
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/jinzhu/gorm"
+)
+
+var (
+	errInvalidCursor      = errors.New("cursor is invalid")
+	errCursorNotSupported = errors.New("cursor pagination is not supported")
+)
+
+// Cursor represents a position in the keyset paginated result
+type Cursor struct {
+	Height uint64
+	ID     int64
+	Prev   bool
+}
+
+// Encode returns an opaque representation of the cursor
+func (c Cursor) Encode() string {
+	dir := "n"
+	if c.Prev {
+		dir = "p"
+	}
+	raw := fmt.Sprintf("%s:%d:%d", dir, c.Height, c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor returns a cursor from its opaque representation
+func DecodeCursor(input string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(input)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	var dir string
+	cursor := &Cursor{}
+
+	n, err := fmt.Sscanf(string(data), "%1s:%d:%d", &dir, &cursor.Height, &cursor.ID)
+	if err != nil || n != 3 {
+		return nil, errInvalidCursor
+	}
+
+	switch dir {
+	case "n":
+	case "p":
+		cursor.Prev = true
+	default:
+		return nil, errInvalidCursor
+	}
+
+	// Reject trailing data and non-canonical numbers
+	if cursor.Encode() != input {
+		return nil, errInvalidCursor
+	}
+
+	return cursor, nil
+}
+
+// paginate applies the offset or keyset pagination to the scope.
+// Records are ordered by the height column and ID in descending order,
+// or by ID alone when the ID is the height, e.g. for blocks.
+func (p Pagination) paginate(scope *gorm.DB, heightColumn string) *gorm.DB {
+	scope = scope.Limit(p.Limit)
+
+	where := "(" + heightColumn + ", id) %s (?, ?)"
+	order := heightColumn + " %[1]s, id %[1]s"
+	if heightColumn == "id" {
+		where, order = "id %s ?", "id %[1]s"
+	}
+
+	if p.cursor == nil {
+		return scope.
+			Order(fmt.Sprintf(order, "DESC")).
+			Offset((p.Page - 1) * p.Limit)
+	}
+
+	args := []interface{}{p.cursor.Height, p.cursor.ID}
+	if heightColumn == "id" {
+		args = args[1:]
+	}
+
+	if p.cursor.Prev {
+		return scope.
+			Where(fmt.Sprintf(where, ">"), args...).
+			Order(fmt.Sprintf(order, "ASC"))
+	}
+
+	return scope.
+		Where(fmt.Sprintf(where, "<"), args...).
+		Order(fmt.Sprintf(order, "DESC"))
+}
+
+// paginateResult builds a paginated result and assigns its cursors.
+// Records fetched with a previous page cursor are reversed to keep the order.
+func (p Pagination) paginateResult(records interface{}, count uint, cursorAt func(i int) Cursor) *PaginatedResult {
+	n := reflect.ValueOf(records).Len()
+
+	result := &PaginatedResult{
+		Page:    p.Page,
+		Limit:   p.Limit,
+		Count:   count,
+		Records: records,
+	}
+	if p.cursor != nil {
+		result.Page = 0
+	}
+
+	if n == 0 {
+		return result.update()
+	}
+
+	if p.cursor != nil && p.cursor.Prev {
+		swap := reflect.Swapper(records)
+		for i, j := 0, n-1; i < j; i, j = i+1, j-1 {
+			swap(i, j)
+		}
+	}
+
+	hasMore := n == int(p.Limit)
+
+	if p.cursor != nil && p.cursor.Prev {
+		if hasMore {
+			result.PrevCursor = p.prevCursor(cursorAt(0))
+		}
+		result.NextCursor = cursorAt(n - 1).Encode()
+	} else {
+		if p.cursor != nil || p.Page > 1 {
+			result.PrevCursor = p.prevCursor(cursorAt(0))
+		}
+		if hasMore {
+			result.NextCursor = cursorAt(n - 1).Encode()
+		}
+	}
+
+	return result.update()
+}
+
+func (p Pagination) prevCursor(c Cursor) string {
+	c.Prev = true
+	return c.Encode()
+}
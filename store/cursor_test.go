@@ -0,0 +1,203 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+)
+
+func encodeRaw(raw string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// normalizeSQL collapses whitespace of the generated SQL
+func normalizeSQL(query string) string {
+	return strings.Join(strings.Fields(query), " ")
+}
+
+func TestCursorEncode(t *testing.T) {
+	examples := []Cursor{
+		{Height: 0, ID: 0},
+		{Height: 100, ID: 5},
+		{Height: 100, ID: 5, Prev: true},
+		{Height: 18446744073709551615, ID: 9223372036854775807},
+		{Height: 1, ID: -3},
+	}
+
+	for _, ex := range examples {
+		cursor, err := DecodeCursor(ex.Encode())
+		if assert.NoError(t, err) {
+			assert.Equal(t, ex, *cursor)
+		}
+	}
+
+	assert.Equal(t, encodeRaw("n:100:5"), Cursor{Height: 100, ID: 5}.Encode())
+	assert.Equal(t, encodeRaw("p:100:5"), Cursor{Height: 100, ID: 5, Prev: true}.Encode())
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	examples := []string{
+		"",
+		"!!!",
+		encodeRaw("n:100:5") + "==",
+		base64.URLEncoding.EncodeToString([]byte("n:100:5")),
+		encodeRaw("x:100:5"),
+		encodeRaw("next:100:5"),
+		encodeRaw("n:100"),
+		encodeRaw("n:100:"),
+		encodeRaw("n:-1:5"),
+		encodeRaw("n:1:2xyz"),
+		encodeRaw("n:1:2:3"),
+		encodeRaw("n:01:2"),
+		encodeRaw("n:+1:2"),
+		encodeRaw("n: 1:2"),
+	}
+
+	for _, ex := range examples {
+		cursor, err := DecodeCursor(ex)
+		assert.Equal(t, errInvalidCursor, err, ex)
+		assert.Nil(t, cursor, ex)
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	conn, _ := sql.Open("postgres", "")
+	db, _ := gorm.Open("postgres", conn)
+	defer db.Close()
+
+	examples := []struct {
+		pagination Pagination
+		column     string
+		sql        string
+		vars       []interface{}
+	}{
+		{
+			pagination: Pagination{Limit: 10},
+			column:     "height",
+			sql:        "ORDER BY height DESC, id DESC LIMIT 10 OFFSET 0",
+		},
+		{
+			pagination: Pagination{Page: 3, Limit: 10},
+			column:     "height",
+			sql:        "ORDER BY height DESC, id DESC LIMIT 10 OFFSET 20",
+		},
+		{
+			pagination: Pagination{Limit: 10, Cursor: Cursor{Height: 100, ID: 5}.Encode()},
+			column:     "height",
+			sql:        "WHERE ((height, id) < ($1, $2)) ORDER BY height DESC, id DESC LIMIT 10",
+			vars:       []interface{}{uint64(100), int64(5)},
+		},
+		{
+			pagination: Pagination{Limit: 10, Cursor: Cursor{Height: 100, ID: 5, Prev: true}.Encode()},
+			column:     "block_height",
+			sql:        "WHERE ((block_height, id) > ($1, $2)) ORDER BY block_height ASC, id ASC LIMIT 10",
+			vars:       []interface{}{uint64(100), int64(5)},
+		},
+		{
+			pagination: Pagination{Limit: 10, Cursor: Cursor{Height: 100, ID: 100}.Encode()},
+			column:     "id",
+			sql:        "WHERE (id < $1) ORDER BY id DESC LIMIT 10",
+			vars:       []interface{}{int64(100)},
+		},
+	}
+
+	for _, ex := range examples {
+		p := ex.pagination
+		if !assert.NoError(t, p.Validate()) {
+			continue
+		}
+
+		scope := p.paginate(db.Table("records"), ex.column).NewScope(nil)
+		assert.Equal(t, ex.sql, normalizeSQL(scope.CombinedConditionSql()))
+		assert.Equal(t, ex.vars, scope.SQLVars)
+	}
+}
+
+func TestPaginateResult(t *testing.T) {
+	type record struct {
+		Height uint64
+		ID     int64
+	}
+
+	cursorAt := func(records []record) func(int) Cursor {
+		return func(i int) Cursor {
+			return Cursor{Height: records[i].Height, ID: records[i].ID}
+		}
+	}
+
+	newPagination := func(p Pagination) Pagination {
+		assert.NoError(t, p.Validate())
+		return p
+	}
+
+	t.Run("first page", func(t *testing.T) {
+		records := []record{{3, 30}, {2, 20}}
+		result := newPagination(Pagination{Limit: 2}).paginateResult(records, 5, cursorAt(records))
+
+		assert.Equal(t, uint(1), result.Page)
+		assert.Equal(t, uint(3), result.Pages)
+		assert.Equal(t, "", result.PrevCursor)
+		assert.Equal(t, Cursor{Height: 2, ID: 20}.Encode(), result.NextCursor)
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		records := []record{{1, 10}}
+		result := newPagination(Pagination{Page: 3, Limit: 2}).paginateResult(records, 5, cursorAt(records))
+
+		assert.Equal(t, uint(3), result.Page)
+		assert.Equal(t, Cursor{Height: 1, ID: 10, Prev: true}.Encode(), result.PrevCursor)
+		assert.Equal(t, "", result.NextCursor)
+	})
+
+	t.Run("next cursor", func(t *testing.T) {
+		cursor := Cursor{Height: 3, ID: 30}.Encode()
+		records := []record{{2, 20}, {1, 10}}
+		result := newPagination(Pagination{Limit: 2, Cursor: cursor}).paginateResult(records, 0, cursorAt(records))
+
+		assert.Equal(t, uint(0), result.Page)
+		assert.Equal(t, Cursor{Height: 2, ID: 20, Prev: true}.Encode(), result.PrevCursor)
+		assert.Equal(t, Cursor{Height: 1, ID: 10}.Encode(), result.NextCursor)
+	})
+
+	t.Run("next cursor on last page", func(t *testing.T) {
+		cursor := Cursor{Height: 2, ID: 20}.Encode()
+		records := []record{{1, 10}}
+		result := newPagination(Pagination{Limit: 2, Cursor: cursor}).paginateResult(records, 0, cursorAt(records))
+
+		assert.Equal(t, Cursor{Height: 1, ID: 10, Prev: true}.Encode(), result.PrevCursor)
+		assert.Equal(t, "", result.NextCursor)
+	})
+
+	t.Run("prev cursor", func(t *testing.T) {
+		cursor := Cursor{Height: 1, ID: 10, Prev: true}.Encode()
+		// Previous pages are fetched in ascending order
+		records := []record{{2, 20}, {3, 30}}
+		result := newPagination(Pagination{Limit: 2, Cursor: cursor}).paginateResult(records, 0, cursorAt(records))
+
+		assert.Equal(t, []record{{3, 30}, {2, 20}}, records)
+		assert.Equal(t, Cursor{Height: 3, ID: 30, Prev: true}.Encode(), result.PrevCursor)
+		assert.Equal(t, Cursor{Height: 2, ID: 20}.Encode(), result.NextCursor)
+	})
+
+	t.Run("prev cursor on first page", func(t *testing.T) {
+		cursor := Cursor{Height: 2, ID: 20, Prev: true}.Encode()
+		records := []record{{3, 30}}
+		result := newPagination(Pagination{Limit: 2, Cursor: cursor}).paginateResult(records, 0, cursorAt(records))
+
+		assert.Equal(t, "", result.PrevCursor)
+		assert.Equal(t, Cursor{Height: 3, ID: 30}.Encode(), result.NextCursor)
+	})
+
+	t.Run("empty page", func(t *testing.T) {
+		records := []record{}
+		result := newPagination(Pagination{}).paginateResult(records, 0, cursorAt(records))
+
+		assert.Equal(t, uint(0), result.Pages)
+		assert.Equal(t, "", result.PrevCursor)
+		assert.Equal(t, "", result.NextCursor)
+	})
+}
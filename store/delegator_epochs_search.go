@@ -2,17 +2,78 @@ package store
 
 import (
 	"errors"
+	"time"
 )
 
 type DelegatorEpochsSearch struct {
 	AccountID   string `form:"account_id"`
 	ValidatorID string `form:"validator_id"`
 	Epoch       string `form:"epoch"`
+	StartDate   string `form:"start_date"`
+	EndDate     string `form:"end_date"`
+
+	startTime *time.Time
+	endTime   *time.Time
 }
 
 func (s *DelegatorEpochsSearch) Validate() error {
 	if s.Epoch == "" && s.AccountID == "" && s.ValidatorID == "" {
 		return errors.New("at least a parameter is required for delegator search (epoch, account id or validator id)")
 	}
+
+	if t, err := parseTimeFilter(s.StartDate); err == nil {
+		s.startTime = t
+	} else {
+		return errors.New("start time is invalid")
+	}
+	if t, err := parseTimeFilter(s.EndDate); err == nil {
+		s.endTime = t
+	} else {
+		return errors.New("end time is invalid")
+	}
+
 	return nil
 }
+
+const (
+	delegatorsSortStake  = "stake"
+	delegatorsSortReward = "reward"
+)
+
+type ValidatorDelegatorsSearch struct {
+	Pagination
+
+	ValidatorID string `form:"-"`
+	Epoch       string `form:"epoch"`
+	Sort        string `form:"sort" enum:"stake,reward"`
+}
+
+func (s *ValidatorDelegatorsSearch) Validate() error {
+	if err := s.Pagination.Validate(); err != nil {
+		return err
+	}
+	if s.Cursor != "" {
+		return errCursorNotSupported
+	}
+	if s.ValidatorID == "" {
+		return errors.New("validator id is required")
+	}
+
+	switch s.Sort {
+	case "":
+		s.Sort = delegatorsSortStake
+	case delegatorsSortStake, delegatorsSortReward:
+	default:
+		return errors.New("invalid sort value: " + s.Sort)
+	}
+
+	return nil
+}
+
+// order returns the sql ordering clause for the search
+func (s *ValidatorDelegatorsSearch) order() string {
+	if s.Sort == delegatorsSortReward {
+		return "reward DESC NULLS LAST, account_id ASC"
+	}
+	return "staked_balance DESC, account_id ASC"
+}
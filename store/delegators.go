@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/figment-networks/indexing-engine/store/bulk"
+	"github.com/jinzhu/gorm"
+
 	"github.com/figment-networks/near-indexer/model"
 	"github.com/figment-networks/near-indexer/store/queries"
 )
@@ -16,12 +18,20 @@ type DelegatorsStore struct {
 	baseStore
 }
 
+// ValidatorDelegatorsResult contains paginated delegators along with epoch summary
+type ValidatorDelegatorsResult struct {
+	*PaginatedResult
+
+	Epoch   string                  `json:"epoch"`
+	Summary model.DelegatorsSummary `json:"summary"`
+}
+
 // FetchRewardsByInterval fetches reward by interval
 func (s *DelegatorsStore) FetchRewardsByInterval(account string, validatorId string, from time.Time, to time.Time, timeInterval model.TimeInterval) ([]model.RewardsSummary, error) {
 	slt := " to_char(distributed_at_time, $INTERVAL) AS interval, validator_id as validator, SUM(reward) AS amount"
 	slt = strings.Replace(slt, "$INTERVAL", "'"+timeInterval.String()+"'", -1)
 
-	scope := s.db.Select(slt).Table("delegator_epochs")
+	scope := s.reader().Select(slt).Table("delegator_epochs")
 
 	if account != "" {
 		scope = scope.Where("account_id = ?", account)
@@ -57,7 +67,7 @@ func (s *DelegatorsStore) FetchRewardsByInterval(account string, validatorId str
 // FindDelegatorEpochBy returns delegator epoch by epoch and account id
 func (s DelegatorsStore) FindDelegatorEpochBy(epoch string, accountId string, validatorId string) (*model.DelegatorEpoch, error) {
 	res := &model.DelegatorEpoch{}
-	err := s.db.Where("distributed_at_epoch = ? AND account_id = ? AND validator_id = ?", epoch, accountId, validatorId).Limit(1).Take(res).Error
+	err := s.reader().Where("distributed_at_epoch = ? AND account_id = ? AND validator_id = ?", epoch, accountId, validatorId).Limit(1).Take(res).Error
 	return res, checkErr(err)
 }
 
@@ -67,7 +77,73 @@ func (s DelegatorsStore) SearchDelegatorEpochs(search DelegatorEpochsSearch) ([]
 		return nil, err
 	}
 
-	scope := s.db.Model(&model.DelegatorEpoch{})
+	delegatorEpochs := []model.DelegatorEpoch{}
+	err := s.delegatorEpochsScope(search).Find(&delegatorEpochs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return delegatorEpochs, nil
+}
+
+// PaginateDelegatorEpochs returns a page of delegator epochs matching the search, most recent first
+func (s DelegatorsStore) PaginateDelegatorEpochs(search DelegatorEpochsSearch, pagination Pagination) (*PaginatedResult, error) {
+	if err := search.Validate(); err != nil {
+		return nil, err
+	}
+	if err := pagination.Validate(); err != nil {
+		return nil, err
+	}
+
+	scope := s.delegatorEpochsScope(search)
+
+	var count uint
+	if !pagination.SkipCount {
+		if err := scope.Count(&count).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	delegatorEpochs := []model.DelegatorEpoch{}
+
+	err := pagination.
+		paginate(scope, "distributed_at_height").
+		Find(&delegatorEpochs).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := pagination.paginateResult(delegatorEpochs, count, func(i int) Cursor {
+		return Cursor{Height: uint64(delegatorEpochs[i].DistributedAtHeight), ID: delegatorEpochs[i].ID}
+	})
+
+	return result, nil
+}
+
+// ExportDelegatorEpochs streams all delegator epochs matching the search in chronological order
+func (s DelegatorsStore) ExportDelegatorEpochs(search DelegatorEpochsSearch, fn func(*model.DelegatorEpoch) error) error {
+	if err := search.Validate(); err != nil {
+		return err
+	}
+
+	query := s.delegatorEpochsScope(search).
+		Order("distributed_at_height ASC, id ASC").
+		QueryExpr()
+
+	return streamQuery(s.reader(), query, func(scan func(interface{}) error) error {
+		record := &model.DelegatorEpoch{}
+		if err := scan(record); err != nil {
+			return err
+		}
+		return fn(record)
+	})
+}
+
+// delegatorEpochsScope returns a query scope for the delegator epochs search filters
+func (s DelegatorsStore) delegatorEpochsScope(search DelegatorEpochsSearch) *gorm.DB {
+	scope := s.reader().Model(&model.DelegatorEpoch{})
 
 	if search.Epoch != "" {
 		scope = scope.Where("epoch = ?", search.Epoch)
@@ -78,14 +154,156 @@ func (s DelegatorsStore) SearchDelegatorEpochs(search DelegatorEpochsSearch) ([]
 	if search.AccountID != "" {
 		scope = scope.Where("account_id = ?", search.AccountID)
 	}
+	if search.startTime != nil {
+		scope = scope.Where("distributed_at_time >= ?", search.startTime)
+	}
+	if search.endTime != nil {
+		scope = scope.Where("distributed_at_time <= ?", search.endTime)
+	}
+
+	return scope
+}
+
+// LastValidatorEpoch returns the most recent epoch with delegations for a validator
+func (s DelegatorsStore) LastValidatorEpoch(validatorID string) (string, error) {
+	res := &model.DelegatorEpoch{}
+
+	err := s.reader().
+		Select("epoch").
+		Where("validator_id = ?", validatorID).
+		Order("distributed_at_height DESC").
+		Limit(1).
+		Take(res).
+		Error
+
+	return res.Epoch, checkErr(err)
+}
+
+// FindByValidators returns the delegator epochs of each of the validators in a single query.
+// Without an epoch, the most recent epoch with delegations of each validator is used.
+func (s DelegatorsStore) FindByValidators(validatorIDs []string, epoch string) (map[string][]model.DelegatorEpoch, error) {
+	result := make(map[string][]model.DelegatorEpoch, len(validatorIDs))
+	if len(validatorIDs) == 0 {
+		return result, nil
+	}
+
+	rows := []struct {
+		BatchKey string
+		model.DelegatorEpoch
+	}{}
+
+	err := batchQuery(s.reader(), validatorIDs, "*", func(validatorID string) *gorm.DB {
+		scope := s.reader().Model(&model.DelegatorEpoch{})
+
+		if epoch != "" {
+			return scope.Where("validator_id = ? AND epoch = ?", validatorID, epoch)
+		}
+
+		lastEpoch := s.reader().
+			Model(&model.DelegatorEpoch{}).
+			Select("epoch").
+			Where("validator_id = ?", validatorID).
+			Order("distributed_at_height DESC").
+			Limit(1).
+			QueryExpr()
+
+		return scope.Where("validator_id = ? AND epoch = (?)", validatorID, lastEpoch)
+	}, "id ASC").Scan(&rows).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		result[row.BatchKey] = append(result[row.BatchKey], row.DelegatorEpoch)
+	}
+	return result, nil
+}
+
+// FindByAccounts returns the delegator epochs of each of the accounts in a single query
+func (s DelegatorsStore) FindByAccounts(accounts []string, validatorID string, epoch string) (map[string][]model.DelegatorEpoch, error) {
+	result := make(map[string][]model.DelegatorEpoch, len(accounts))
+	if len(accounts) == 0 {
+		return result, nil
+	}
+
+	scope := s.reader().
+		Model(&model.DelegatorEpoch{}).
+		Where("account_id IN (?)", accounts)
+
+	if validatorID != "" {
+		scope = scope.Where("validator_id = ?", validatorID)
+	}
+	if epoch != "" {
+		scope = scope.Where("epoch = ?", epoch)
+	}
+
+	records := []model.DelegatorEpoch{}
+	if err := scope.Order("id ASC").Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		result[record.AccountID] = append(result[record.AccountID], record)
+	}
+	return result, nil
+}
+
+// PaginateValidatorDelegators returns a paginated list of validator delegators for an epoch
+func (s DelegatorsStore) PaginateValidatorDelegators(search ValidatorDelegatorsSearch) (*ValidatorDelegatorsResult, error) {
+	if err := search.Validate(); err != nil {
+		return nil, err
+	}
+
+	if search.Epoch == "" {
+		epoch, err := s.LastValidatorEpoch(search.ValidatorID)
+		if err == ErrNotFound {
+			// Validator does not have any delegations yet
+			result := &PaginatedResult{
+				Page:    search.Page,
+				Limit:   search.Limit,
+				Records: []model.DelegatorEpoch{},
+			}
+			return &ValidatorDelegatorsResult{PaginatedResult: result}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		search.Epoch = epoch
+	}
+
+	summary := model.DelegatorsSummary{}
+	if err := s.reader().Raw(queries.DelegatorsSummary, search.ValidatorID, search.Epoch).Scan(&summary).Error; err != nil {
+		return nil, err
+	}
 
 	delegatorEpochs := []model.DelegatorEpoch{}
-	err := scope.Find(&delegatorEpochs).Error
+
+	err := s.reader().
+		Model(&model.DelegatorEpoch{}).
+		Where("validator_id = ? AND epoch = ?", search.ValidatorID, search.Epoch).
+		Order(search.order()).
+		Offset((search.Page - 1) * search.Limit).
+		Limit(search.Limit).
+		Find(&delegatorEpochs).
+		Error
+
 	if err != nil {
 		return nil, err
 	}
 
-	return delegatorEpochs, nil
+	result := &PaginatedResult{
+		Page:    search.Page,
+		Limit:   search.Limit,
+		Count:   uint(summary.DelegatorsCount),
+		Records: delegatorEpochs,
+	}
+
+	return &ValidatorDelegatorsResult{
+		PaginatedResult: result.update(),
+		Epoch:           search.Epoch,
+		Summary:         summary,
+	}, nil
 }
 
 // ImportDelegatorEpochs creates new validators in batch
@@ -1,7 +1,11 @@
 package store
 
 import (
+	"strings"
+	"time"
+
 	"github.com/figment-networks/indexing-engine/store/bulk"
+	"github.com/figment-networks/indexing-engine/store/jsonquery"
 	"github.com/figment-networks/near-indexer/model"
 	"github.com/figment-networks/near-indexer/store/queries"
 )
@@ -15,7 +19,7 @@ type EpochsStore struct {
 func (s EpochsStore) FindByID(id string) (*model.Epoch, error) {
 	epoch := &model.Epoch{}
 
-	err := s.db.
+	err := s.reader().
 		Model(epoch).
 		Take(epoch, "id = ?", id).
 		Error
@@ -23,11 +27,18 @@ func (s EpochsStore) FindByID(id string) (*model.Epoch, error) {
 	return epoch, checkErr(err)
 }
 
+// FindByIDs returns epochs for the given IDs
+func (s EpochsStore) FindByIDs(ids []string) ([]model.Epoch, error) {
+	result := []model.Epoch{}
+	err := s.reader().Where("id IN (?)", ids).Find(&result).Error
+	return result, err
+}
+
 // Recent returns a set of recent epochs
 func (s EpochsStore) Recent(limit int) ([]model.Epoch, error) {
 	epochs := []model.Epoch{}
 
-	err := s.db.
+	err := s.reader().
 		Model(&model.Epoch{}).
 		Order("start_height DESC").
 		Limit(limit).
@@ -37,12 +48,63 @@ func (s EpochsStore) Recent(limit int) ([]model.Epoch, error) {
 	return epochs, checkErr(err)
 }
 
-// UpdateCounts updates epoch counts for a given set of epoch IDs
-func (s EpochsStore) UpdateCounts(ids []string) error {
+// Stats returns a time series of epochs stake and supply stats
+func (s EpochsStore) Stats(limit uint) ([]byte, error) {
+	return jsonquery.MustArray(s.reader(), queries.EpochsStats, limit)
+}
+
+// EconomicsByInterval returns network supply changes grouped by a time interval
+func (s EpochsStore) EconomicsByInterval(from time.Time, to time.Time, timeInterval model.TimeInterval) ([]model.EconomicsSummary, error) {
+	slt := `
+		to_char(start_time, $INTERVAL) AS interval,
+		COUNT(1) AS epochs_count,
+		(ARRAY_AGG(total_supply - total_supply_delta ORDER BY start_height ASC))[1] AS start_supply,
+		(ARRAY_AGG(total_supply ORDER BY start_height DESC))[1] AS end_supply,
+		SUM(total_supply_delta) AS total_supply_delta,
+		SUM(minted) AS minted,
+		SUM(balance_burnt) AS balance_burnt,
+		SUM(treasury_reward) AS treasury_reward,
+		COALESCE(ROUND(SUM(minted) * 100 / NULLIF((ARRAY_AGG(total_supply - total_supply_delta ORDER BY start_height ASC))[1], 0), 8), 0) AS inflation`
+	slt = strings.Replace(slt, "$INTERVAL", "'"+timeInterval.String()+"'", -1)
+
+	scope := s.reader().
+		Select(slt).
+		Table("epochs").
+		Where("total_supply IS NOT NULL")
+
+	if !from.IsZero() {
+		scope = scope.Where("start_time > ?", from)
+	}
+	if !to.IsZero() {
+		scope = scope.Where("start_time < ?", to)
+	}
+
+	grp := " to_char(start_time, $INTERVAL)"
+	grp = strings.Replace(grp, "$INTERVAL", "'"+timeInterval.String()+"'", -1)
+	scope = scope.Group(grp).Order(grp)
+
+	res := []model.EconomicsSummary{}
+	if err := scope.Find(&res).Error; err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// UpdateCounts updates epoch counts for a given set of epoch IDs.
+// Treasury reward is computed using the protocol reward rate fraction, and
+// is left empty when the rate is unknown.
+func (s EpochsStore) UpdateCounts(ids []string, rewardRate []int64) error {
 	if len(ids) == 0 {
 		return nil
 	}
-	return s.db.Exec(queries.EpochsUpdateCounts, ids).Error
+
+	var num, den int64
+	if len(rewardRate) == 2 {
+		num, den = rewardRate[0], rewardRate[1]
+	}
+
+	return s.db.Exec(queries.EpochsUpdateCounts, ids, num, den).Error
 }
 
 // Import created epochs records in batch
@@ -59,6 +121,9 @@ func (s EpochsStore) Import(records []model.Epoch) error {
 			r.BlocksCount,
 			r.ValidatorsCount,
 			r.AverageEfficiency,
+			r.SeatPrice,
+			r.TotalStake,
+			r.NakamotoCoefficient,
 		}
 	})
 }
@@ -1,7 +1,11 @@
 package store
 
 import (
+	"strconv"
+
 	"github.com/figment-networks/indexing-engine/store/bulk"
+	"github.com/jinzhu/gorm"
+
 	"github.com/figment-networks/near-indexer/model"
 	"github.com/figment-networks/near-indexer/store/queries"
 )
@@ -25,7 +29,7 @@ type EventsSearch struct {
 func (s EventsStore) FindByID(id int) (*model.Event, error) {
 	event := &model.Event{}
 
-	err := s.db.
+	err := s.reader().
 		Model(event).
 		Take(event, "id = ?", id).
 		Error
@@ -33,16 +37,27 @@ func (s EventsStore) FindByID(id int) (*model.Event, error) {
 	return event, checkErr(err)
 }
 
+// FindByHeight returns all events recorded at a given height
+func (s EventsStore) FindByHeight(height uint64) ([]model.Event, error) {
+	result := []model.Event{}
+
+	err := s.reader().
+		Model(&model.Event{}).
+		Order("id DESC").
+		Find(&result, "block_height = ?", height).
+		Error
+
+	return result, checkErr(err)
+}
+
 // Search performs an event search and returns matching records
 func (s EventsStore) Search(search EventsSearch) (*PaginatedResult, error) {
 	if err := search.Validate(); err != nil {
 		return nil, err
 	}
 
-	scope := s.db.
-		Model(&model.Event{}).
-		Order("id DESC").
-		Limit(search.Limit)
+	scope := s.reader().
+		Model(&model.Event{})
 
 	if search.ItemID != "" && search.ItemType != "" {
 		scope = scope.Where("item_id = ? AND item_type = ?", search.ItemID, search.ItemType)
@@ -58,15 +73,16 @@ func (s EventsStore) Search(search EventsSearch) (*PaginatedResult, error) {
 	}
 
 	var count uint
-	if err := scope.Count(&count).Error; err != nil {
-		return nil, err
+	if !search.SkipCount {
+		if err := scope.Count(&count).Error; err != nil {
+			return nil, err
+		}
 	}
 
 	events := []model.Event{}
 
-	err := scope.
-		Offset((search.Page - 1) * search.Limit).
-		Limit(search.Limit).
+	err := search.
+		paginate(scope, "block_height").
 		Find(&events).
 		Error
 
@@ -74,14 +90,77 @@ func (s EventsStore) Search(search EventsSearch) (*PaginatedResult, error) {
 		return nil, err
 	}
 
-	result := &PaginatedResult{
-		Page:    search.Page,
-		Limit:   search.Limit,
-		Count:   count,
-		Records: events,
+	result := search.paginateResult(events, count, func(i int) Cursor {
+		return Cursor{Height: events[i].BlockHeight, ID: int64(events[i].ID)}
+	})
+
+	return result, nil
+}
+
+// SearchByItems returns a page of events for each of the items in a single query
+func (s EventsStore) SearchByItems(itemType string, ids []string, pagination Pagination) (map[string]*PaginatedResult, error) {
+	return s.searchBatch(ids, pagination, func(id string) *gorm.DB {
+		return s.reader().Model(&model.Event{}).Where("item_id = ? AND item_type = ?", id, itemType)
+	})
+}
+
+// SearchByEpochs returns a page of events for each of the epochs in a single query
+func (s EventsStore) SearchByEpochs(epochs []string, pagination Pagination) (map[string]*PaginatedResult, error) {
+	return s.searchBatch(epochs, pagination, func(epoch string) *gorm.DB {
+		return s.reader().Model(&model.Event{}).Where("epoch = ?", epoch)
+	})
+}
+
+// SearchByHeights returns a page of events for each of the block heights in a single query.
+// Results are keyed by the formatted height.
+func (s EventsStore) SearchByHeights(heights []uint64, pagination Pagination) (map[string]*PaginatedResult, error) {
+	keys := make([]string, len(heights))
+	for i, height := range heights {
+		keys[i] = strconv.FormatUint(height, 10)
+	}
+
+	return s.searchBatch(keys, pagination, func(height string) *gorm.DB {
+		return s.reader().Model(&model.Event{}).Where("block_height = ?", height)
+	})
+}
+
+// searchBatch returns a page of events for the scope of each key
+func (s EventsStore) searchBatch(keys []string, pagination Pagination, scope func(key string) *gorm.DB) (map[string]*PaginatedResult, error) {
+	if err := pagination.Validate(); err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return map[string]*PaginatedResult{}, nil
+	}
+
+	counts, err := pagination.countBatch(s.reader(), keys, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []struct {
+		BatchKey string
+		model.Event
+	}{}
+
+	if err := pagination.paginateBatch(s.reader(), keys, "block_height", scope).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	grouped := map[string][]model.Event{}
+	for _, row := range rows {
+		grouped[row.BatchKey] = append(grouped[row.BatchKey], row.Event)
+	}
+
+	result := make(map[string]*PaginatedResult, len(keys))
+	for _, key := range keys {
+		events := append([]model.Event{}, grouped[key]...)
+		result[key] = pagination.paginateResult(events, counts[key], func(i int) Cursor {
+			return Cursor{Height: events[i].BlockHeight, ID: int64(events[i].ID)}
+		})
 	}
 
-	return result.update(), nil
+	return result, nil
 }
 
 func (s EventsStore) Import(records []model.Event) error {
@@ -0,0 +1,58 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/jinzhu/gorm"
+)
+
+const (
+	exportBatchSize = 1000
+)
+
+// streamQuery iterates over the query results using a server-side cursor,
+// fetching records in batches to keep the memory usage flat.
+func streamQuery(db *gorm.DB, query *gorm.SqlExpr, fn func(scan func(dst interface{}) error) error) error {
+	tx := db.Begin()
+	if err := tx.Error; err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := tx.Exec("DECLARE export_cursor NO SCROLL CURSOR FOR ?", query).Error; err != nil {
+		return err
+	}
+
+	fetchQuery := fmt.Sprintf("FETCH %d FROM export_cursor", exportBatchSize)
+
+	for {
+		rows, err := tx.Raw(fetchQuery).Rows()
+		if err != nil {
+			return err
+		}
+
+		count := 0
+		scan := func(dst interface{}) error {
+			return tx.ScanRows(rows, dst)
+		}
+
+		for rows.Next() {
+			count++
+			if err := fn(scan); err != nil {
+				rows.Close()
+				return err
+			}
+		}
+		if err := rows.Err(); err != nil {
+			rows.Close()
+			return err
+		}
+		rows.Close()
+
+		if count < exportBatchSize {
+			break
+		}
+	}
+
+	return tx.Commit().Error
+}
@@ -0,0 +1,29 @@
+-- +goose Up
+ALTER TABLE validators ADD COLUMN expected_chunks INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE validators ADD COLUMN produced_chunks INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE validators ADD COLUMN uptime NUMERIC;
+
+ALTER TABLE validator_epochs ADD COLUMN expected_chunks INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE validator_epochs ADD COLUMN produced_chunks INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE validator_epochs ADD COLUMN uptime NUMERIC;
+
+ALTER TABLE validator_aggregates ADD COLUMN expected_chunks INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE validator_aggregates ADD COLUMN produced_chunks INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE validator_aggregates ADD COLUMN uptime NUMERIC;
+
+UPDATE validators SET uptime = efficiency;
+UPDATE validator_epochs SET uptime = efficiency;
+UPDATE validator_aggregates SET uptime = efficiency;
+
+-- +goose Down
+ALTER TABLE validators DROP COLUMN expected_chunks;
+ALTER TABLE validators DROP COLUMN produced_chunks;
+ALTER TABLE validators DROP COLUMN uptime;
+
+ALTER TABLE validator_epochs DROP COLUMN expected_chunks;
+ALTER TABLE validator_epochs DROP COLUMN produced_chunks;
+ALTER TABLE validator_epochs DROP COLUMN uptime;
+
+ALTER TABLE validator_aggregates DROP COLUMN expected_chunks;
+ALTER TABLE validator_aggregates DROP COLUMN produced_chunks;
+ALTER TABLE validator_aggregates DROP COLUMN uptime;
@@ -0,0 +1,23 @@
+-- +goose Up
+CREATE TABLE validator_proposals (
+  id         BIGSERIAL NOT NULL PRIMARY KEY,
+  height     INTEGER NOT NULL,
+  time       TIMESTAMP WITH TIME ZONE NOT NULL,
+  epoch      VARCHAR NOT NULL,
+  account_id VARCHAR NOT NULL,
+  public_key VARCHAR,
+  stake      DECIMAL(65, 0) NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_validator_proposals_height_account
+  ON validator_proposals(height, account_id);
+
+CREATE INDEX idx_validator_proposals_account_id
+  ON validator_proposals(account_id, height DESC);
+
+CREATE INDEX idx_validator_proposals_epoch
+  ON validator_proposals(epoch);
+
+-- +goose Down
+DROP TABLE validator_proposals;
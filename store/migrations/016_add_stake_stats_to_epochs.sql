@@ -0,0 +1,13 @@
+-- +goose Up
+ALTER TABLE epochs ADD COLUMN seat_price DECIMAL(65, 0);
+ALTER TABLE epochs ADD COLUMN total_stake DECIMAL(65, 0);
+ALTER TABLE epochs ADD COLUMN nakamoto_coefficient INTEGER;
+ALTER TABLE epochs ADD COLUMN total_supply DECIMAL(65, 0);
+ALTER TABLE epochs ADD COLUMN total_supply_delta DECIMAL(65, 0);
+
+-- +goose Down
+ALTER TABLE epochs DROP COLUMN seat_price;
+ALTER TABLE epochs DROP COLUMN total_stake;
+ALTER TABLE epochs DROP COLUMN nakamoto_coefficient;
+ALTER TABLE epochs DROP COLUMN total_supply;
+ALTER TABLE epochs DROP COLUMN total_supply_delta;
@@ -0,0 +1,15 @@
+-- +goose Up
+ALTER TABLE blocks ADD COLUMN balance_burnt DECIMAL(65, 0);
+
+ALTER TABLE epochs ADD COLUMN balance_burnt DECIMAL(65, 0);
+ALTER TABLE epochs ADD COLUMN minted DECIMAL(65, 0);
+ALTER TABLE epochs ADD COLUMN treasury_reward DECIMAL(65, 0);
+ALTER TABLE epochs ADD COLUMN inflation NUMERIC;
+
+-- +goose Down
+ALTER TABLE blocks DROP COLUMN balance_burnt;
+
+ALTER TABLE epochs DROP COLUMN balance_burnt;
+ALTER TABLE epochs DROP COLUMN minted;
+ALTER TABLE epochs DROP COLUMN treasury_reward;
+ALTER TABLE epochs DROP COLUMN inflation;
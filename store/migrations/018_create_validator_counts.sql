@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE validator_counts (
+  id            SERIAL NOT NULL PRIMARY KEY,
+  height        INTEGER NOT NULL,
+  time          TIMESTAMP WITH TIME ZONE NOT NULL,
+  total_count   INTEGER NOT NULL,
+  active_count  INTEGER NOT NULL,
+  slashed_count INTEGER NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_validator_counts_height
+  ON validator_counts(height);
+
+CREATE INDEX idx_validator_counts_time
+  ON validator_counts(time);
+
+-- +goose Down
+DROP TABLE validator_counts;
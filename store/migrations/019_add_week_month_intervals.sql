@@ -0,0 +1,6 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+ALTER TYPE e_interval ADD VALUE IF NOT EXISTS 'mon';
+
+-- +goose Down
+-- Postgres does not support removing values from enum types
@@ -0,0 +1,26 @@
+-- +goose Up
+ALTER TABLE transactions ADD COLUMN tokens_burnt DECIMAL(65, 0);
+
+CREATE TABLE transaction_stats (
+  id                 SERIAL NOT NULL PRIMARY KEY,
+  time               TIMESTAMP WITH TIME ZONE NOT NULL,
+  bucket             e_interval NOT NULL,
+
+  transactions_count INTEGER,
+  success_count      INTEGER,
+  failure_count      INTEGER,
+  actions_counts     JSONB,
+  senders_count      INTEGER,
+  receivers_count    INTEGER,
+  gas_burnt          DECIMAL(65, 0),
+  tokens_burnt       DECIMAL(65, 0),
+  amount             DECIMAL(65, 0)
+);
+
+CREATE UNIQUE INDEX idx_transaction_stats_bucket
+  ON transaction_stats(time, bucket);
+
+-- +goose Down
+DROP TABLE transaction_stats;
+
+ALTER TABLE transactions DROP COLUMN tokens_burnt;
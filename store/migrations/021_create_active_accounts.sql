@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE active_accounts (
+  id                 SERIAL NOT NULL PRIMARY KEY,
+  time               TIMESTAMP WITH TIME ZONE NOT NULL,
+  bucket             e_interval NOT NULL,
+
+  signers_count      INTEGER,
+  receivers_count    INTEGER,
+  accounts_count     INTEGER,
+  new_accounts_count INTEGER
+);
+
+CREATE UNIQUE INDEX idx_active_accounts_bucket
+  ON active_accounts(time, bucket);
+
+-- +goose Down
+DROP TABLE active_accounts;
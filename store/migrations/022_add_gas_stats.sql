@@ -0,0 +1,27 @@
+-- +goose Up
+ALTER TABLE blocks ALTER COLUMN gas_price TYPE DECIMAL(65, 0) USING NULLIF(gas_price, '')::DECIMAL(65, 0);
+ALTER TABLE blocks ALTER COLUMN gas_limit TYPE BIGINT;
+ALTER TABLE blocks ALTER COLUMN gas_used TYPE BIGINT;
+
+ALTER TABLE transactions ADD COLUMN fee DECIMAL(65, 0);
+
+ALTER TABLE block_stats ADD COLUMN gas_price_min DECIMAL(65, 0);
+ALTER TABLE block_stats ADD COLUMN gas_price_avg DECIMAL(65, 0);
+ALTER TABLE block_stats ADD COLUMN gas_price_max DECIMAL(65, 0);
+ALTER TABLE block_stats ADD COLUMN gas_used BIGINT;
+ALTER TABLE block_stats ADD COLUMN gas_limit BIGINT;
+ALTER TABLE block_stats ADD COLUMN gas_utilization NUMERIC;
+
+-- +goose Down
+ALTER TABLE block_stats DROP COLUMN gas_utilization;
+ALTER TABLE block_stats DROP COLUMN gas_limit;
+ALTER TABLE block_stats DROP COLUMN gas_used;
+ALTER TABLE block_stats DROP COLUMN gas_price_max;
+ALTER TABLE block_stats DROP COLUMN gas_price_avg;
+ALTER TABLE block_stats DROP COLUMN gas_price_min;
+
+ALTER TABLE transactions DROP COLUMN fee;
+
+ALTER TABLE blocks ALTER COLUMN gas_used TYPE INTEGER;
+ALTER TABLE blocks ALTER COLUMN gas_limit TYPE INTEGER;
+ALTER TABLE blocks ALTER COLUMN gas_price TYPE VARCHAR;
@@ -0,0 +1,6 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+ALTER TYPE e_interval ADD VALUE IF NOT EXISTS 'min';
+
+-- +goose Down
+-- Postgres does not support removing values from enum types
@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE INDEX idx_transactions_height_id
+  ON transactions(height, id);
+
+CREATE INDEX idx_events_block_height_id
+  ON events(block_height, id);
+
+CREATE INDEX idx_validator_epochs_last_height_id
+  ON validator_epochs(account_id, last_height, id);
+
+-- +goose Down
+DROP INDEX idx_validator_epochs_last_height_id;
+DROP INDEX idx_events_block_height_id;
+DROP INDEX idx_transactions_height_id;
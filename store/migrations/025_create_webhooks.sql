@@ -0,0 +1,41 @@
+-- +goose Up
+CREATE TABLE webhooks (
+  id           SERIAL NOT NULL PRIMARY KEY,
+  url          VARCHAR NOT NULL,
+  secret       VARCHAR NOT NULL,
+  actions      TEXT[],
+  validator_id VARCHAR NOT NULL DEFAULT '',
+  account      VARCHAR NOT NULL DEFAULT '',
+  active       BOOLEAN NOT NULL DEFAULT TRUE,
+  created_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at   TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_webhooks_account
+  ON webhooks(account);
+
+CREATE TABLE webhook_deliveries (
+  id              SERIAL NOT NULL PRIMARY KEY,
+  webhook_id      INTEGER NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+  event_type      VARCHAR NOT NULL,
+  source_id       BIGINT NOT NULL,
+  payload         JSONB NOT NULL,
+  status          VARCHAR NOT NULL,
+  attempts        INTEGER NOT NULL DEFAULT 0,
+  next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  response_code   INTEGER NOT NULL DEFAULT 0,
+  error           TEXT NOT NULL DEFAULT '',
+  delivered_at    TIMESTAMP WITH TIME ZONE,
+  created_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at      TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_webhook_deliveries_source
+  ON webhook_deliveries(webhook_id, event_type, source_id);
+
+CREATE INDEX idx_webhook_deliveries_status
+  ON webhook_deliveries(status, next_attempt_at);
+
+-- +goose Down
+DROP TABLE webhook_deliveries;
+DROP TABLE webhooks;
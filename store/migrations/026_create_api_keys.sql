@@ -0,0 +1,30 @@
+-- +goose Up
+CREATE TABLE api_keys (
+  id           SERIAL NOT NULL PRIMARY KEY,
+  name         VARCHAR NOT NULL,
+  prefix       VARCHAR NOT NULL,
+  key_hash     VARCHAR NOT NULL,
+  rate_limit   DOUBLE PRECISION NOT NULL DEFAULT 0,
+  rate_burst   INTEGER NOT NULL DEFAULT 0,
+  daily_quota  BIGINT NOT NULL DEFAULT 0,
+  admin        BOOLEAN NOT NULL DEFAULT FALSE,
+  active       BOOLEAN NOT NULL DEFAULT TRUE,
+  last_used_at TIMESTAMP WITH TIME ZONE,
+  created_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at   TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_api_keys_key_hash
+  ON api_keys(key_hash);
+
+CREATE TABLE api_key_usage (
+  api_key_id INTEGER NOT NULL REFERENCES api_keys(id) ON DELETE CASCADE,
+  day        DATE NOT NULL,
+  requests   BIGINT NOT NULL DEFAULT 0,
+  rejected   BIGINT NOT NULL DEFAULT 0,
+  PRIMARY KEY (api_key_id, day)
+);
+
+-- +goose Down
+DROP TABLE api_key_usage;
+DROP TABLE api_keys;
@@ -0,0 +1,10 @@
+-- +goose Up
+CREATE INDEX idx_accounts_name_prefix
+  ON accounts(name text_pattern_ops);
+
+CREATE INDEX idx_validator_aggregates_account_id_prefix
+  ON validator_aggregates(account_id text_pattern_ops);
+
+-- +goose Down
+DROP INDEX idx_validator_aggregates_account_id_prefix;
+DROP INDEX idx_accounts_name_prefix;
@@ -0,0 +1,40 @@
+-- +goose Up
+CREATE TABLE receipts (
+  id               SERIAL NOT NULL PRIMARY KEY,
+  receipt_id       TEXT NOT NULL,
+  transaction_hash TEXT NOT NULL,
+  height           INTEGER NOT NULL,
+  time             TIMESTAMP WITH TIME ZONE NOT NULL,
+  signer           VARCHAR NOT NULL,
+  receiver         VARCHAR NOT NULL,
+  gas_burnt        VARCHAR,
+  tokens_burnt     VARCHAR,
+  success          BOOLEAN,
+  created_at       TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at       TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_receipts_receipt_id
+  ON receipts(receipt_id);
+
+CREATE INDEX idx_receipts_receiver_height_id
+  ON receipts(receiver, height, id);
+
+CREATE INDEX idx_transactions_sender_height_id
+  ON transactions(sender, height, id);
+
+CREATE INDEX idx_transactions_receiver_height_id
+  ON transactions(receiver, height, id);
+
+CREATE INDEX idx_delegator_epochs_account_height_id
+  ON delegator_epochs(account_id, distributed_at_height, id);
+
+CREATE INDEX idx_events_item_block_height_id
+  ON events(item_id, block_height, id);
+
+-- +goose Down
+DROP INDEX idx_events_item_block_height_id;
+DROP INDEX idx_delegator_epochs_account_height_id;
+DROP INDEX idx_transactions_receiver_height_id;
+DROP INDEX idx_transactions_sender_height_id;
+DROP TABLE receipts;
@@ -0,0 +1,4 @@
+-- +goose Up
+UPDATE transactions SET fee = tokens_burnt WHERE tokens_burnt IS NOT NULL;
+
+-- +goose Down
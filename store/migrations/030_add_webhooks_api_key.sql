@@ -0,0 +1,10 @@
+-- +goose Up
+ALTER TABLE webhooks ADD COLUMN api_key_id INTEGER REFERENCES api_keys(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_webhooks_api_key_id
+  ON webhooks(api_key_id);
+
+-- +goose Down
+DROP INDEX idx_webhooks_api_key_id;
+
+ALTER TABLE webhooks DROP COLUMN api_key_id;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE block_stats ALTER COLUMN gas_used TYPE DECIMAL(65, 0);
+ALTER TABLE block_stats ALTER COLUMN gas_limit TYPE DECIMAL(65, 0);
+
+-- +goose Down
+ALTER TABLE block_stats ALTER COLUMN gas_limit TYPE BIGINT;
+ALTER TABLE block_stats ALTER COLUMN gas_used TYPE BIGINT;
@@ -0,0 +1,24 @@
+package store
+
+import (
+	"encoding/json"
+)
+
+const (
+	// BlocksChannel is the Postgres notification channel for new indexed blocks
+	BlocksChannel = "near_indexer_blocks"
+)
+
+// BlockNotification is published once the block data has been indexed
+type BlockNotification struct {
+	Height uint64 `json:"height"`
+}
+
+// NotifyBlock publishes a new block notification
+func (s BlocksStore) NotifyBlock(height uint64) error {
+	payload, err := json.Marshal(BlockNotification{Height: height})
+	if err != nil {
+		return err
+	}
+	return s.db.Exec("SELECT pg_notify(?, ?)", BlocksChannel, string(payload)).Error
+}
@@ -5,16 +5,22 @@ const (
 )
 
 type Pagination struct {
-	Page  uint `form:"page"`
-	Limit uint `form:"limit"`
+	Page      uint   `form:"page"`
+	Limit     uint   `form:"limit"`
+	Cursor    string `form:"cursor"`
+	SkipCount bool   `form:"skip_count"`
+
+	cursor *Cursor
 }
 
 type PaginatedResult struct {
-	Page    uint        `json:"page"`
-	Pages   uint        `json:"pages"`
-	Limit   uint        `json:"limit"`
-	Count   uint        `json:"count"`
-	Records interface{} `json:"records"`
+	Page       uint        `json:"page"`
+	Pages      uint        `json:"pages"`
+	Limit      uint        `json:"limit"`
+	Count      uint        `json:"count"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+	PrevCursor string      `json:"prev_cursor,omitempty"`
+	Records    interface{} `json:"records"`
 }
 
 func (p *Pagination) Validate() error {
@@ -27,6 +33,13 @@ func (p *Pagination) Validate() error {
 	if p.Limit >= paginationLimit {
 		p.Limit = paginationLimit
 	}
+	if p.Cursor != "" {
+		cursor, err := DecodeCursor(p.Cursor)
+		if err != nil {
+			return err
+		}
+		p.cursor = cursor
+	}
 	return nil
 }
 
@@ -0,0 +1,17 @@
+SELECT
+  time,
+  bucket,
+  blocks_count,
+  gas_price_min::TEXT,
+  gas_price_avg::TEXT,
+  gas_price_max::TEXT,
+  gas_used::TEXT,
+  gas_limit::TEXT,
+  gas_utilization
+FROM
+  block_stats
+WHERE
+  bucket = $1
+ORDER BY
+  time DESC
+LIMIT $2
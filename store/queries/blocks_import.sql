@@ -8,6 +8,7 @@ INSERT INTO blocks (
   gas_limit,
   gas_used,
   total_supply,
+  balance_burnt,
   chunks_count,
   transactions_count,
   approvals_count,
@@ -0,0 +1,15 @@
+SELECT
+  time,
+  bucket,
+  blocks_count,
+  block_time_avg,
+  validators_count,
+  transactions_count
+FROM
+  block_stats
+WHERE
+  bucket = $1
+  AND time >= $2
+  AND time <= $3
+ORDER BY
+  time DESC
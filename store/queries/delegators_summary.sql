@@ -0,0 +1,16 @@
+WITH selected_delegators AS (
+  SELECT
+    staked_balance,
+    ROW_NUMBER() OVER (ORDER BY staked_balance DESC) AS position
+  FROM
+    delegator_epochs
+  WHERE
+    validator_id = $1 AND epoch = $2
+)
+SELECT
+  COUNT(1) AS delegators_count,
+  COALESCE(SUM(staked_balance), 0) AS total_staked,
+  COALESCE(SUM(staked_balance) FILTER (WHERE position <= 10), 0) AS top10_staked,
+  COALESCE(ROUND(SUM(staked_balance) FILTER (WHERE position <= 10) * 100 / NULLIF(SUM(staked_balance), 0), 2), 0) AS top10_concentration
+FROM
+  selected_delegators
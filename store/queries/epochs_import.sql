@@ -6,11 +6,17 @@ INSERT INTO epochs (
   end_time,
   blocks_count,
   validators_count,
-  average_efficiency
+  average_efficiency,
+  seat_price,
+  total_stake,
+  nakamoto_coefficient
 )
 VALUES @values
 
 ON CONFLICT (id) DO UPDATE
 SET
-  end_height = excluded.end_height,
-  end_time   = excluded.end_time
+  end_height           = excluded.end_height,
+  end_time             = excluded.end_time,
+  seat_price           = COALESCE(excluded.seat_price, epochs.seat_price),
+  total_stake          = COALESCE(excluded.total_stake, epochs.total_stake),
+  nakamoto_coefficient = COALESCE(excluded.nakamoto_coefficient, epochs.nakamoto_coefficient)
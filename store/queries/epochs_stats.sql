@@ -0,0 +1,21 @@
+SELECT * FROM (
+  SELECT
+    id,
+    start_height,
+    start_time,
+    end_height,
+    end_time,
+    validators_count,
+    seat_price::TEXT AS seat_price,
+    total_stake::TEXT AS total_stake,
+    nakamoto_coefficient,
+    total_supply::TEXT AS total_supply,
+    total_supply_delta::TEXT AS total_supply_delta
+  FROM
+    epochs
+  ORDER BY
+    start_height DESC
+  LIMIT $1
+) recent_epochs
+ORDER BY
+  start_height ASC
@@ -6,13 +6,27 @@ WITH epoch_stats AS (
     MAX(time) AS end_time,
     MAX(id) AS end_height,
     COUNT(1) AS blocks_count,
-    COUNT(DISTINCT producer) AS validators_count
+    COUNT(DISTINCT producer) AS validators_count,
+    COALESCE(SUM(balance_burnt), 0) AS balance_burnt
   FROM
     blocks
   WHERE
     epoch IN (?)
   GROUP BY
     epoch
+),
+epoch_supply AS (
+  SELECT
+    epoch,
+    (
+      SELECT NULLIF(total_supply, '')::DECIMAL FROM blocks WHERE id = epoch_stats.end_height
+    ) AS end_supply,
+    COALESCE(
+      (SELECT NULLIF(total_supply, '')::DECIMAL FROM blocks WHERE id < epoch_stats.start_height ORDER BY id DESC LIMIT 1),
+      (SELECT NULLIF(total_supply, '')::DECIMAL FROM blocks WHERE id = epoch_stats.start_height)
+    ) AS start_supply
+  FROM
+    epoch_stats
 )
 UPDATE epochs
 SET
@@ -24,8 +38,20 @@ SET
   validators_count   = epoch_stats.validators_count,
   average_efficiency = (
     SELECT ROUND(COALESCE(AVG(efficiency), 0), 4) FROM validator_epochs WHERE epoch = epoch_stats.epoch
+  ),
+  total_supply       = epoch_supply.end_supply,
+  total_supply_delta = epoch_supply.end_supply - epoch_supply.start_supply,
+  balance_burnt      = epoch_stats.balance_burnt,
+  -- Tokens are minted at the epoch start and burnt with every chunk
+  minted             = epoch_supply.end_supply - epoch_supply.start_supply + epoch_stats.balance_burnt,
+  -- Protocol treasury receives the protocol_reward_rate share of the minted tokens
+  treasury_reward    = FLOOR((epoch_supply.end_supply - epoch_supply.start_supply + epoch_stats.balance_burnt) * ? / NULLIF(?, 0)),
+  inflation          = ROUND(
+    (epoch_supply.end_supply - epoch_supply.start_supply + epoch_stats.balance_burnt) * 100 / NULLIF(epoch_supply.start_supply, 0), 8
   )
 FROM
   epoch_stats
+INNER JOIN epoch_supply
+  ON epoch_supply.epoch = epoch_stats.epoch
 WHERE
   epochs.id = epoch_stats.epoch
@@ -0,0 +1,14 @@
+WITH usage AS (
+  INSERT INTO api_key_usage (api_key_id, day, requests, rejected)
+  VALUES ($1, $2, $3, $4)
+  ON CONFLICT (api_key_id, day) DO UPDATE
+  SET
+    requests = api_key_usage.requests + excluded.requests,
+    rejected = api_key_usage.rejected + excluded.rejected
+  RETURNING requests
+), last_used AS (
+  UPDATE api_keys
+  SET last_used_at = $5
+  WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < $5)
+)
+SELECT requests FROM usage
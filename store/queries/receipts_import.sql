@@ -0,0 +1,18 @@
+INSERT INTO receipts (
+  receipt_id,
+  transaction_hash,
+  height,
+  time,
+  signer,
+  receiver,
+  gas_burnt,
+  tokens_burnt,
+  success,
+  created_at,
+  updated_at
+)
+VALUES @values
+
+ON CONFLICT (receipt_id) DO UPDATE
+SET
+  updated_at = excluded.updated_at
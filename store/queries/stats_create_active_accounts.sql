@@ -0,0 +1,31 @@
+INSERT INTO active_accounts (
+  time,
+  bucket,
+  signers_count,
+  receivers_count,
+  accounts_count,
+  new_accounts_count
+)
+SELECT
+  DATE_TRUNC('@bucket', time) AS time,
+  '@bucket' AS bucket,
+  COUNT(DISTINCT sender) AS signers_count,
+  COUNT(DISTINCT receiver) AS receivers_count,
+  COUNT(DISTINCT account) AS accounts_count,
+  COUNT(DISTINCT receiver) FILTER (
+    WHERE success IS TRUE AND actions @> '[{"type": "CreateAccount"}]'::JSONB
+  ) AS new_accounts_count
+FROM
+  transactions,
+  UNNEST(ARRAY[sender, receiver]) AS account
+WHERE
+  time >= $1::timestamp AND time <= $2::timestamp
+GROUP BY
+  DATE_TRUNC('@bucket', time)
+
+ON CONFLICT (time, bucket) DO UPDATE
+SET
+  signers_count      = excluded.signers_count,
+  receivers_count    = excluded.receivers_count,
+  accounts_count     = excluded.accounts_count,
+  new_accounts_count = excluded.new_accounts_count
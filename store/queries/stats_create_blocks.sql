@@ -4,7 +4,13 @@ INSERT INTO block_stats (
 	blocks_count,
 	block_time_avg,
   validators_count,
-  transactions_count
+  transactions_count,
+  gas_price_min,
+  gas_price_avg,
+  gas_price_max,
+  gas_used,
+  gas_limit,
+  gas_utilization
 )
 SELECT
 	DATE_TRUNC('@bucket', time) AS time,
@@ -12,7 +18,13 @@ SELECT
 	COUNT(1) AS blocks_count,
 	ROUND(EXTRACT(EPOCH FROM (MAX(time) - MIN(time)) / COUNT(1))::NUMERIC, 2) AS block_time_avg,
   COALESCE(MAX(approvals_count), 0) AS validators_count,
-	COALESCE(SUM(transactions_count), 0) AS transactions_count
+	COALESCE(SUM(transactions_count), 0) AS transactions_count,
+  MIN(gas_price) AS gas_price_min,
+  ROUND(AVG(gas_price)) AS gas_price_avg,
+  MAX(gas_price) AS gas_price_max,
+  COALESCE(SUM(gas_used), 0) AS gas_used,
+  COALESCE(SUM(gas_limit), 0) AS gas_limit,
+  ROUND(COALESCE(SUM(gas_used)::NUMERIC / NULLIF(SUM(gas_limit), 0) * 100, 0), 2) AS gas_utilization
 FROM
 	blocks
 WHERE
@@ -25,4 +37,10 @@ SET
 	blocks_count       = excluded.blocks_count,
 	block_time_avg     = excluded.block_time_avg,
   validators_count   = excluded.validators_count,
-  transactions_count = excluded.transactions_count
+  transactions_count = excluded.transactions_count,
+  gas_price_min      = excluded.gas_price_min,
+  gas_price_avg      = excluded.gas_price_avg,
+  gas_price_max      = excluded.gas_price_max,
+  gas_used           = excluded.gas_used,
+  gas_limit          = excluded.gas_limit,
+  gas_utilization    = excluded.gas_utilization
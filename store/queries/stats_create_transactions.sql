@@ -0,0 +1,77 @@
+WITH bucket_transactions AS (
+  SELECT
+    DATE_TRUNC('@bucket', time) AS bucket_time,
+    sender,
+    receiver,
+    success,
+    actions,
+    NULLIF(gas_burnt, '')::DECIMAL AS gas_burnt,
+    tokens_burnt,
+    NULLIF(amount, '')::DECIMAL AS amount
+  FROM
+    transactions
+  WHERE
+    time >= $1::timestamp AND time <= $2::timestamp
+),
+bucket_actions AS (
+  SELECT
+    bucket_time,
+    JSONB_OBJECT_AGG(action_type, actions_count) AS actions_counts
+  FROM (
+    SELECT
+      bucket_time,
+      action->>'type' AS action_type,
+      COUNT(1) AS actions_count
+    FROM
+      bucket_transactions,
+      JSONB_ARRAY_ELEMENTS(COALESCE(actions, '[]'::JSONB)) AS action
+    GROUP BY
+      bucket_time, action->>'type'
+  ) action_types
+  GROUP BY
+    bucket_time
+)
+INSERT INTO transaction_stats (
+  time,
+  bucket,
+  transactions_count,
+  success_count,
+  failure_count,
+  actions_counts,
+  senders_count,
+  receivers_count,
+  gas_burnt,
+  tokens_burnt,
+  amount
+)
+SELECT
+  bucket_transactions.bucket_time AS time,
+  '@bucket' AS bucket,
+  COUNT(1) AS transactions_count,
+  COUNT(1) FILTER (WHERE success IS TRUE) AS success_count,
+  COUNT(1) FILTER (WHERE success IS NOT TRUE) AS failure_count,
+  COALESCE(bucket_actions.actions_counts, '{}'::JSONB) AS actions_counts,
+  COUNT(DISTINCT sender) AS senders_count,
+  COUNT(DISTINCT receiver) AS receivers_count,
+  COALESCE(SUM(gas_burnt), 0) AS gas_burnt,
+  COALESCE(SUM(tokens_burnt), 0) AS tokens_burnt,
+  COALESCE(SUM(amount), 0) AS amount
+FROM
+  bucket_transactions
+LEFT JOIN bucket_actions
+  ON bucket_actions.bucket_time = bucket_transactions.bucket_time
+GROUP BY
+  bucket_transactions.bucket_time,
+  bucket_actions.actions_counts
+
+ON CONFLICT (time, bucket) DO UPDATE
+SET
+  transactions_count = excluded.transactions_count,
+  success_count      = excluded.success_count,
+  failure_count      = excluded.failure_count,
+  actions_counts     = excluded.actions_counts,
+  senders_count      = excluded.senders_count,
+  receivers_count    = excluded.receivers_count,
+  gas_burnt          = excluded.gas_burnt,
+  tokens_burnt       = excluded.tokens_burnt,
+  amount             = excluded.amount
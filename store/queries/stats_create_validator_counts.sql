@@ -0,0 +1,25 @@
+INSERT INTO validator_counts (
+  height,
+  time,
+  total_count,
+  active_count,
+  slashed_count
+)
+SELECT
+  height,
+  MIN(time) AS time,
+  COUNT(1) AS total_count,
+  COUNT(1) FILTER (WHERE slashed IS NOT TRUE) AS active_count,
+  COUNT(1) FILTER (WHERE slashed IS TRUE) AS slashed_count
+FROM
+  validators
+WHERE
+  height >= $1 AND height <= $2
+GROUP BY
+  height
+
+ON CONFLICT (height) DO UPDATE
+SET
+  total_count   = excluded.total_count,
+  active_count  = excluded.active_count,
+  slashed_count = excluded.slashed_count
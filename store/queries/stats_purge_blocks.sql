@@ -0,0 +1,5 @@
+DELETE FROM
+  block_stats
+WHERE
+  bucket = $1
+  AND time < $2
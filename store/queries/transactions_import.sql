@@ -7,6 +7,8 @@ INSERT INTO transactions (
   receiver,
   amount,
   gas_burnt,
+  tokens_burnt,
+  fee,
   success,
   actions,
   actions_count,
@@ -0,0 +1,19 @@
+SELECT
+  time,
+  bucket,
+  transactions_count,
+  success_count,
+  failure_count,
+  actions_counts,
+  senders_count,
+  receivers_count,
+  gas_burnt::TEXT AS gas_burnt,
+  tokens_burnt::TEXT AS tokens_burnt,
+  amount::TEXT AS amount
+FROM
+  transaction_stats
+WHERE
+  bucket = $1
+ORDER BY
+  time DESC
+LIMIT $2
@@ -0,0 +1,8 @@
+SELECT
+  COALESCE(
+    CASE
+      WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+      ELSE EXTRACT(EPOCH FROM NOW() - pg_last_xact_replay_timestamp())
+    END,
+    0
+  ) AS lag
@@ -6,9 +6,12 @@ INSERT INTO validator_aggregates(
   account_id,
   expected_blocks,
   produced_blocks,
+  expected_chunks,
+  produced_chunks,
   slashed,
   stake,
   efficiency,
+  uptime,
   active,
   reward_fee,
   created_at,
@@ -22,7 +25,10 @@ SET
   last_time       = excluded.last_time,
   expected_blocks = COALESCE((SELECT SUM(expected_blocks) FROM validator_epochs WHERE account_id = excluded.account_id LIMIT 1), 0),
   produced_blocks = COALESCE((SELECT SUM(produced_blocks) FROM validator_epochs WHERE account_id = excluded.account_id LIMIT 1), 0),
+  expected_chunks = COALESCE((SELECT SUM(expected_chunks) FROM validator_epochs WHERE account_id = excluded.account_id LIMIT 1), 0),
+  produced_chunks = COALESCE((SELECT SUM(produced_chunks) FROM validator_epochs WHERE account_id = excluded.account_id LIMIT 1), 0),
   efficiency      = COALESCE((SELECT AVG(efficiency) FROM validator_epochs WHERE account_id = excluded.account_id LIMIT 1), 0),
+  uptime          = COALESCE((SELECT AVG(uptime) FROM validator_epochs WHERE account_id = excluded.account_id LIMIT 1), 0),
   stake           = excluded.stake,
   slashed         = excluded.slashed,
   active          = excluded.active,
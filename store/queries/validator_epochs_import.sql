@@ -5,7 +5,10 @@ INSERT INTO validator_epochs (
   last_time,
   expected_blocks,
   produced_blocks,
+  expected_chunks,
+  produced_chunks,
   efficiency,
+  uptime,
   staking_balance,
   reward_fee
 )
@@ -17,6 +20,9 @@ SET
   last_time       = excluded.last_time,
   expected_blocks = excluded.expected_blocks,
   produced_blocks = excluded.produced_blocks,
+  expected_chunks = excluded.expected_chunks,
+  produced_chunks = excluded.produced_chunks,
   efficiency      = ROUND(excluded.efficiency, 4),
+  uptime          = ROUND(excluded.uptime, 4),
   staking_balance = excluded.staking_balance,
   reward_fee      = COALESCE(excluded.reward_fee, validator_epochs.reward_fee)
\ No newline at end of file
@@ -0,0 +1,12 @@
+INSERT INTO validator_proposals (
+  height,
+  time,
+  epoch,
+  account_id,
+  public_key,
+  stake,
+  created_at
+)
+VALUES @values
+
+ON CONFLICT (height, account_id) DO NOTHING
@@ -5,9 +5,12 @@ INSERT INTO validators (
   epoch,
   expected_blocks,
   produced_blocks,
+  expected_chunks,
+  produced_chunks,
   slashed,
   stake,
   efficiency,
+  uptime,
   reward_fee,
   created_at,
   updated_at
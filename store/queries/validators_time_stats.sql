@@ -0,0 +1,19 @@
+SELECT
+  time,
+  bucket,
+  total_min,
+  total_max,
+  total_avg,
+  active_min,
+  active_max,
+  active_avg,
+  slashed_min,
+  slashed_max,
+  slashed_avg
+FROM
+  validator_stats
+WHERE
+  bucket = $1
+ORDER BY
+  time DESC
+LIMIT $2
@@ -0,0 +1,33 @@
+WITH due AS (
+  SELECT
+    id,
+    ROW_NUMBER() OVER (PARTITION BY webhook_id ORDER BY next_attempt_at ASC, id ASC) AS position
+  FROM
+    webhook_deliveries
+  WHERE
+    status = $1 AND next_attempt_at <= NOW()
+),
+claimed AS (
+  SELECT
+    webhook_deliveries.id
+  FROM
+    webhook_deliveries
+  INNER JOIN due
+    ON due.id = webhook_deliveries.id
+  WHERE
+    due.position <= $2
+  ORDER BY
+    webhook_deliveries.next_attempt_at ASC, webhook_deliveries.id ASC
+  LIMIT $3
+  FOR UPDATE OF webhook_deliveries SKIP LOCKED
+)
+UPDATE webhook_deliveries
+SET
+  next_attempt_at = NOW() + $4 * INTERVAL '1 second',
+  updated_at      = NOW()
+FROM
+  claimed
+WHERE
+  webhook_deliveries.id = claimed.id
+RETURNING
+  webhook_deliveries.*
@@ -0,0 +1,32 @@
+INSERT INTO webhook_deliveries (
+  webhook_id,
+  event_type,
+  source_id,
+  payload,
+  status,
+  attempts,
+  next_attempt_at,
+  created_at,
+  updated_at
+)
+SELECT
+  webhooks.id,
+  'event',
+  events.id,
+  JSONB_BUILD_OBJECT('webhook_id', webhooks.id, 'type', 'event', 'data', TO_JSONB(events)),
+  'pending',
+  0,
+  NOW(),
+  NOW(),
+  NOW()
+FROM
+  events
+INNER JOIN webhooks
+  ON webhooks.active = TRUE
+  AND webhooks.account = ''
+  AND (COALESCE(CARDINALITY(webhooks.actions), 0) = 0 OR events.action = ANY(webhooks.actions))
+  AND (webhooks.validator_id = '' OR (events.item_type = 'validator' AND events.item_id = webhooks.validator_id))
+WHERE
+  events.block_height >= $1 AND events.block_height <= $2
+
+ON CONFLICT (webhook_id, event_type, source_id) DO NOTHING
@@ -0,0 +1,36 @@
+INSERT INTO webhook_deliveries (
+  webhook_id,
+  event_type,
+  source_id,
+  payload,
+  status,
+  attempts,
+  next_attempt_at,
+  created_at,
+  updated_at
+)
+SELECT
+  webhooks.id,
+  'transaction',
+  transactions.id,
+  JSONB_BUILD_OBJECT(
+    'webhook_id', webhooks.id,
+    'type', 'transaction',
+    -- Amounts are sent as strings, same as in the API
+    'data', TO_JSONB(transactions) || JSONB_BUILD_OBJECT('tokens_burnt', transactions.tokens_burnt::TEXT, 'fee', transactions.fee::TEXT)
+  ),
+  'pending',
+  0,
+  NOW(),
+  NOW(),
+  NOW()
+FROM
+  transactions
+INNER JOIN webhooks
+  ON webhooks.active = TRUE
+  AND webhooks.account <> ''
+  AND (transactions.sender = webhooks.account OR transactions.receiver = webhooks.account)
+WHERE
+  transactions.height >= $1 AND transactions.height <= $2
+
+ON CONFLICT (webhook_id, event_type, source_id) DO NOTHING
@@ -0,0 +1,37 @@
+package store
+
+import (
+	"time"
+
+	"github.com/figment-networks/indexing-engine/store/bulk"
+
+	"github.com/figment-networks/near-indexer/model"
+	"github.com/figment-networks/near-indexer/store/queries"
+)
+
+// ReceiptsStore handles operations on receipts
+type ReceiptsStore struct {
+	baseStore
+}
+
+// Import imports receipts in bulk
+func (s ReceiptsStore) Import(records []model.Receipt) error {
+	t := time.Now()
+
+	return s.bulkImport(queries.ReceiptsImport, len(records), func(i int) bulk.Row {
+		r := records[i]
+		return bulk.Row{
+			r.ReceiptID,
+			r.TransactionHash,
+			r.Height,
+			r.Time,
+			r.Signer,
+			r.Receiver,
+			r.GasBurnt,
+			r.TokensBurnt,
+			r.Success,
+			t,
+			t,
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package store
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/figment-networks/near-indexer/store/queries"
+)
+
+const (
+	// replicaCheckInterval is the interval between replication lag checks
+	replicaCheckInterval = 5 * time.Second
+)
+
+// ReplicaStatus contains the read replica availability and replication lag
+type ReplicaStatus struct {
+	Available bool    `json:"available"`
+	Lag       float64 `json:"lag"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// replicaSet routes reads to the read replicas in round-robin fashion, falling
+// back to the primary when none of the replicas is available or up to date.
+type replicaSet struct {
+	primary  *gorm.DB
+	replicas []*replica
+	maxLag   time.Duration
+	next     uint32
+}
+
+type replica struct {
+	db *gorm.DB
+
+	mu     sync.RWMutex
+	status ReplicaStatus
+}
+
+// reader returns a connection for read queries
+func (r *replicaSet) reader() *gorm.DB {
+	n := len(r.replicas)
+	if n == 0 {
+		return r.primary
+	}
+
+	start := int(atomic.AddUint32(&r.next, 1))
+	for i := 0; i < n; i++ {
+		rep := r.replicas[(start+i)%n]
+		if rep.available() {
+			return rep.db
+		}
+	}
+
+	return r.primary
+}
+
+// check updates the replication lag of all replicas
+func (r *replicaSet) check() {
+	for _, rep := range r.replicas {
+		rep.check(r.maxLag)
+	}
+}
+
+// monitor periodically checks the replicas in background
+func (r *replicaSet) monitor() {
+	go func() {
+		for range time.Tick(replicaCheckInterval) {
+			r.check()
+		}
+	}()
+}
+
+func (r *replicaSet) statuses() []ReplicaStatus {
+	if r == nil {
+		return nil
+	}
+
+	result := make([]ReplicaStatus, len(r.replicas))
+	for i, rep := range r.replicas {
+		rep.mu.RLock()
+		result[i] = rep.status
+		rep.mu.RUnlock()
+	}
+	return result
+}
+
+func (r *replicaSet) close() {
+	for _, rep := range r.replicas {
+		rep.db.Close()
+	}
+}
+
+func (r *replica) available() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.status.Available
+}
+
+// check marks the replica as unavailable when it can't be queried or its
+// replication lag is over the limit, e.g. while the primary is catching up.
+func (r *replica) check(maxLag time.Duration) {
+	var lag float64
+	err := r.db.DB().QueryRow(queries.UtilReplicationLag).Scan(&lag)
+	r.update(lag, err, maxLag)
+}
+
+// update sets the replica status from the result of the replication lag query
+func (r *replica) update(lag float64, err error, maxLag time.Duration) {
+	status := ReplicaStatus{Lag: lag}
+
+	if err != nil {
+		status.Error = err.Error()
+	} else {
+		status.Available = status.Lag <= maxLag.Seconds()
+	}
+
+	r.mu.Lock()
+	r.status = status
+	r.mu.Unlock()
+}
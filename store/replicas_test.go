@@ -0,0 +1,66 @@
+package store
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplicaUpdate(t *testing.T) {
+	examples := []struct {
+		lag       float64
+		err       error
+		available bool
+		message   string
+	}{
+		{lag: 0, available: true},
+		{lag: 10, available: true},
+		{lag: 10.5, available: false},
+		{lag: 0, err: errors.New("connection refused"), available: false, message: "connection refused"},
+	}
+
+	for _, ex := range examples {
+		rep := &replica{}
+		rep.update(ex.lag, ex.err, 10*time.Second)
+
+		assert.Equal(t, ex.available, rep.available())
+		assert.Equal(t, ex.message, rep.status.Error)
+	}
+}
+
+func TestReplicaSetReader(t *testing.T) {
+	primary := &gorm.DB{}
+	first := &replica{db: &gorm.DB{}}
+	second := &replica{db: &gorm.DB{}}
+
+	set := &replicaSet{
+		primary:  primary,
+		replicas: []*replica{first, second},
+		maxLag:   time.Second,
+	}
+
+	// None of the replicas is checked yet
+	assert.True(t, set.reader() == primary)
+
+	// Replicas are used in turns
+	first.update(0, nil, set.maxLag)
+	second.update(0, nil, set.maxLag)
+	readers := []*gorm.DB{set.reader(), set.reader()}
+	assert.True(t, readers[0] != readers[1])
+	assert.True(t, readers[0] != primary && readers[1] != primary)
+
+	// Lagging replica is skipped
+	first.update(5, nil, set.maxLag)
+	assert.True(t, set.reader() == second.db)
+	assert.True(t, set.reader() == second.db)
+
+	// Unavailable replicas fall back to the primary
+	second.update(0, errors.New("connection refused"), set.maxLag)
+	assert.True(t, set.reader() == primary)
+
+	// No replicas configured
+	assert.True(t, (&replicaSet{primary: primary}).reader() == primary)
+}
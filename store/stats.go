@@ -5,13 +5,17 @@ import (
 	"strings"
 	"time"
 
+	"github.com/figment-networks/near-indexer/model"
 	"github.com/figment-networks/near-indexer/model/util"
 	"github.com/figment-networks/near-indexer/store/queries"
 )
 
 const (
-	BucketHour = "h"
-	BucketDay  = "d"
+	BucketMinute = "min"
+	BucketHour   = "h"
+	BucketDay    = "d"
+	BucketWeek   = "w"
+	BucketMonth  = "mon"
 )
 
 type StatsStore struct {
@@ -54,30 +58,86 @@ func (s StatsStore) CreateBlockStats(bucket string, timeRange TimeRange) error {
 	return s.db.Exec(query, timeFrom, timeTo).Error
 }
 
+// PurgeBlockStats removes block stats for a time bucket created before a given time
+func (s StatsStore) PurgeBlockStats(bucket string, before time.Time) (int64, error) {
+	result := s.db.Exec(queries.StatsPurgeBlocks, bucket, before)
+	return result.RowsAffected, result.Error
+}
+
+// CreateTransactionStats populates transaction stats for a time bucket
+func (s StatsStore) CreateTransactionStats(bucket string, timeRange TimeRange) error {
+	timeFrom, timeTo, err := timeRange.FullRange(bucket)
+	if err != nil {
+		return err
+	}
+	query := s.prepareBucket(queries.StatsCreateTransactions, bucket)
+	return s.db.Exec(query, timeFrom, timeTo).Error
+}
+
+// CreateActiveAccounts populates active accounts counts for a time bucket
+func (s StatsStore) CreateActiveAccounts(bucket string, timeRange TimeRange) error {
+	timeFrom, timeTo, err := timeRange.FullRange(bucket)
+	if err != nil {
+		return err
+	}
+	query := s.prepareBucket(queries.StatsCreateActiveAccounts, bucket)
+	return s.db.Exec(query, timeFrom, timeTo).Error
+}
+
+// CreateValidatorCounts populates validator counts for given block height range
+func (s StatsStore) CreateValidatorCounts(heightRange HeightRange) error {
+	return s.db.Exec(queries.StatsCreateValidatorCounts, heightRange.Start, heightRange.End).Error
+}
+
 // CreateValidatorsStats populates validators stats for a time bucket
-func (s StatsStore) CreateValidatorsStats(bucket string, ts time.Time) error {
-	start, end, err := getTimeRange(bucket, ts)
+func (s StatsStore) CreateValidatorsStats(bucket string, timeRange TimeRange) error {
+	timeFrom, timeTo, err := timeRange.FullRange(bucket)
 	if err != nil {
 		return err
 	}
 	query := s.prepareBucket(queries.StatsCreateValidators, bucket)
+	return s.db.Exec(query, timeFrom, timeTo).Error
+}
 
-	return s.db.Exec(query, start, end).Error
+// PurgeValidatorCounts removes validator counts that are no longer needed for stats
+func (s StatsStore) PurgeValidatorCounts() (int64, error) {
+	result := s.db.Exec(queries.ValidatorsPurgeCounts)
+	return result.RowsAffected, result.Error
 }
 
 // getTimeRange returns the start/end time for a given time bucket
 func getTimeRange(bucket string, ts time.Time) (start time.Time, end time.Time, err error) {
 	switch bucket {
+	case BucketMinute:
+		start, end = util.MinuteInterval(ts)
 	case BucketHour:
 		start, end = util.HourInterval(ts)
 	case BucketDay:
 		start, end = util.DayInterval(ts)
+	case BucketWeek:
+		start, end = util.WeekInterval(ts)
+	case BucketMonth:
+		start, end = util.MonthInterval(ts)
 	default:
 		err = errors.New("invalid time bucket")
 	}
 	return
 }
 
+// intervalBucket returns the stats time bucket for a given time interval
+func intervalBucket(interval model.TimeInterval) (string, error) {
+	switch interval {
+	case model.TimeIntervalDaily:
+		return BucketDay, nil
+	case model.TimeIntervalWeekly:
+		return BucketWeek, nil
+	case model.TimeIntervalMonthly:
+		return BucketMonth, nil
+	default:
+		return "", errors.New("time interval is not supported")
+	}
+}
+
 // prepareBucket replaces references of time bucket in the query
 func (s StatsStore) prepareBucket(q, bucket string) string {
 	return strings.ReplaceAll(q, "@bucket", bucket)
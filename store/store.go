@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/jinzhu/gorm"
 	_ "github.com/jinzhu/gorm/dialects/postgres"
@@ -14,17 +15,23 @@ import (
 
 // Store handles all database operations
 type Store struct {
-	db *gorm.DB
-
-	Blocks        BlocksStore
-	Epochs        EpochsStore
-	Accounts      AccountsStore
-	Delegators    DelegatorsStore
-	Validators    ValidatorsStore
-	ValidatorAggs ValidatorAggsStore
-	Transactions  TransactionsStore
-	Stats         StatsStore
-	Events        EventsStore
+	db       *gorm.DB
+	replicas *replicaSet
+
+	Blocks             BlocksStore
+	Epochs             EpochsStore
+	Accounts           AccountsStore
+	Delegators         DelegatorsStore
+	Validators         ValidatorsStore
+	ValidatorAggs      ValidatorAggsStore
+	ValidatorProposals ValidatorProposalsStore
+	Transactions       TransactionsStore
+	Stats              StatsStore
+	Events             EventsStore
+	Webhooks           WebhooksStore
+	APIKeys            APIKeysStore
+	Receipts           ReceiptsStore
+	AccountActivity    AccountActivityStore
 }
 
 // Test checks the connection status
@@ -34,9 +41,42 @@ func (s *Store) Test() error {
 
 // Close closes the database connection
 func (s *Store) Close() error {
+	if s.replicas != nil {
+		s.replicas.close()
+	}
 	return s.db.Close()
 }
 
+// UseReplicas connects to the read replicas and routes all reads to them.
+// Replicas with the replication lag over maxLag are not used until they catch up.
+func (s *Store) UseReplicas(connStrs []string, maxLag time.Duration) error {
+	for _, connStr := range connStrs {
+		conn, err := gorm.Open("postgres", connStr)
+		if err != nil {
+			s.replicas.close()
+			return err
+		}
+		s.replicas.replicas = append(s.replicas.replicas, &replica{db: conn})
+	}
+	s.replicas.maxLag = maxLag
+
+	s.replicas.check()
+	s.replicas.monitor()
+
+	return nil
+}
+
+// Replicas returns the status of all read replicas
+func (s *Store) Replicas() []ReplicaStatus {
+	return s.replicas.statuses()
+}
+
+// Primary returns a store that performs all reads on the primary database,
+// for the data that must be read right after it's written
+func (s *Store) Primary() *Store {
+	return newStore(s.db, nil)
+}
+
 // Conn returns an underlying database connection
 func (s *Store) Conn() *sql.DB {
 	return s.db.DB()
@@ -89,17 +129,29 @@ func New(connStr string) (*Store, error) {
 		return nil, err
 	}
 
+	return newStore(conn, &replicaSet{primary: conn}), nil
+}
+
+// newStore returns a new store using the replicas for reads.
+// Webhooks and API keys are managed by the API, so they are always read from the primary.
+func newStore(conn *gorm.DB, replicas *replicaSet) *Store {
 	return &Store{
-		db: conn,
-
-		Blocks:        BlocksStore{scoped(conn, model.Block{})},
-		Epochs:        EpochsStore{scoped(conn, model.Epoch{})},
-		Accounts:      AccountsStore{scoped(conn, model.Account{})},
-		Delegators:    DelegatorsStore{scoped(conn, model.DelegatorEpoch{})},
-		Validators:    ValidatorsStore{scoped(conn, model.Validator{})},
-		ValidatorAggs: ValidatorAggsStore{scoped(conn, model.ValidatorAgg{})},
-		Transactions:  TransactionsStore{scoped(conn, model.Transaction{})},
-		Events:        EventsStore{scoped(conn, model.Event{})},
-		Stats:         StatsStore{baseStore{db: conn}},
-	}, nil
+		db:       conn,
+		replicas: replicas,
+
+		Blocks:             BlocksStore{scoped(conn, replicas, model.Block{})},
+		Epochs:             EpochsStore{scoped(conn, replicas, model.Epoch{})},
+		Accounts:           AccountsStore{scoped(conn, replicas, model.Account{})},
+		Delegators:         DelegatorsStore{scoped(conn, replicas, model.DelegatorEpoch{})},
+		Validators:         ValidatorsStore{scoped(conn, replicas, model.Validator{})},
+		ValidatorAggs:      ValidatorAggsStore{scoped(conn, replicas, model.ValidatorAgg{})},
+		ValidatorProposals: ValidatorProposalsStore{scoped(conn, replicas, model.ValidatorProposal{})},
+		Transactions:       TransactionsStore{scoped(conn, replicas, model.Transaction{})},
+		Events:             EventsStore{scoped(conn, replicas, model.Event{})},
+		Webhooks:           WebhooksStore{scoped(conn, nil, model.Webhook{})},
+		APIKeys:            APIKeysStore{scoped(conn, nil, model.APIKey{})},
+		Receipts:           ReceiptsStore{scoped(conn, replicas, model.Receipt{})},
+		AccountActivity:    AccountActivityStore{scoped(conn, replicas, nil)},
+		Stats:              StatsStore{scoped(conn, replicas, nil)},
+	}
 }
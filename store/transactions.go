@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/figment-networks/indexing-engine/store/bulk"
+	"github.com/figment-networks/indexing-engine/store/jsonquery"
+	"github.com/jinzhu/gorm"
 
 	"github.com/figment-networks/near-indexer/model"
 	"github.com/figment-networks/near-indexer/store/queries"
@@ -18,7 +20,7 @@ type TransactionsStore struct {
 func (s TransactionsStore) FindByBlock(hash string) ([]model.Transaction, error) {
 	result := []model.Transaction{}
 
-	err := s.db.
+	err := s.reader().
 		Model(&model.Transaction{}).
 		Order("id DESC").
 		Limit(100).
@@ -28,11 +30,54 @@ func (s TransactionsStore) FindByBlock(hash string) ([]model.Transaction, error)
 	return result, checkErr(err)
 }
 
+// FindByBlocks returns the transactions of each of the block hashes in a single query
+func (s TransactionsStore) FindByBlocks(hashes []string) (map[string][]model.Transaction, error) {
+	result := make(map[string][]model.Transaction, len(hashes))
+	if len(hashes) == 0 {
+		return result, nil
+	}
+
+	rows := []struct {
+		BatchKey string
+		model.Transaction
+	}{}
+
+	err := batchQuery(s.reader(), hashes, "*", func(hash string) *gorm.DB {
+		return s.reader().
+			Model(&model.Transaction{}).
+			Where("block_hash = ?", hash).
+			Order("id DESC").
+			Limit(100)
+	}, "id DESC").Scan(&rows).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		result[row.BatchKey] = append(result[row.BatchKey], row.Transaction)
+	}
+	return result, nil
+}
+
+// AllByBlock returns all transactions for a block hash in the order of inclusion
+func (s TransactionsStore) AllByBlock(hash string) ([]model.Transaction, error) {
+	result := []model.Transaction{}
+
+	err := s.reader().
+		Model(&model.Transaction{}).
+		Order("id ASC").
+		Find(&result, "block_hash = ?", hash).
+		Error
+
+	return result, checkErr(err)
+}
+
 // FindByHash returns a transaction record by hash
 func (s TransactionsStore) FindByHash(hash string) (*model.Transaction, error) {
 	result := &model.Transaction{}
 
-	err := s.db.
+	err := s.reader().
 		Model(result).
 		Take(result, "hash = ?", hash).
 		Limit(1).
@@ -45,7 +90,7 @@ func (s TransactionsStore) FindByHash(hash string) (*model.Transaction, error) {
 func (s TransactionsStore) Recent(n int) ([]model.Transaction, error) {
 	result := []model.Transaction{}
 
-	err := s.db.
+	err := s.reader().
 		Model(&model.Transaction{}).
 		Order("id DESC").
 		Limit(n).
@@ -61,10 +106,97 @@ func (s TransactionsStore) Search(search TransactionsSearch) (*PaginatedResult,
 		return nil, err
 	}
 
-	scope := s.db.
-		Model(&model.Transaction{}).
-		Order("id DESC").
-		Limit(search.Limit)
+	scope := s.searchScope(search)
+
+	var count uint
+	if !search.SkipCount {
+		if err := scope.Count(&count).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	transactions := []model.Transaction{}
+
+	err := search.
+		paginate(scope, "height").
+		Find(&transactions).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := search.paginateResult(transactions, count, func(i int) Cursor {
+		return Cursor{Height: uint64(transactions[i].Height), ID: transactions[i].ID}
+	})
+
+	return result, nil
+}
+
+// SearchByAccounts returns a page of transactions for each of the accounts in a single query
+func (s TransactionsStore) SearchByAccounts(accounts []string, pagination Pagination) (map[string]*PaginatedResult, error) {
+	if err := pagination.Validate(); err != nil {
+		return nil, err
+	}
+	if len(accounts) == 0 {
+		return map[string]*PaginatedResult{}, nil
+	}
+
+	scope := func(account string) *gorm.DB {
+		return s.reader().Model(&model.Transaction{}).Where("sender = ? OR receiver = ?", account, account)
+	}
+
+	counts, err := pagination.countBatch(s.reader(), accounts, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []struct {
+		BatchKey string
+		model.Transaction
+	}{}
+
+	if err := pagination.paginateBatch(s.reader(), accounts, "height", scope).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	grouped := map[string][]model.Transaction{}
+	for _, row := range rows {
+		grouped[row.BatchKey] = append(grouped[row.BatchKey], row.Transaction)
+	}
+
+	result := make(map[string]*PaginatedResult, len(accounts))
+	for _, account := range accounts {
+		transactions := append([]model.Transaction{}, grouped[account]...)
+		result[account] = pagination.paginateResult(transactions, counts[account], func(i int) Cursor {
+			return Cursor{Height: uint64(transactions[i].Height), ID: transactions[i].ID}
+		})
+	}
+
+	return result, nil
+}
+
+// Export streams all transactions matching the search in chronological order
+func (s TransactionsStore) Export(search TransactionsSearch, fn func(*model.Transaction) error) error {
+	if err := search.Validate(); err != nil {
+		return err
+	}
+
+	query := s.searchScope(search).Order("height ASC, id ASC").QueryExpr()
+
+	return streamQuery(s.reader(), query, func(scan func(interface{}) error) error {
+		tx := &model.Transaction{}
+		if err := scan(tx); err != nil {
+			return err
+		}
+		return fn(tx)
+	})
+}
+
+// searchScope returns a query scope for the transaction search filters
+func (s TransactionsStore) searchScope(search TransactionsSearch) *gorm.DB {
+	scope := s.reader().
+		Model(&model.Transaction{})
 
 	if search.BlockHash != "" {
 		scope = scope.Where("block_hash = ?", search.BlockHash)
@@ -92,31 +224,12 @@ func (s TransactionsStore) Search(search TransactionsSearch) (*PaginatedResult,
 		scope = scope.Where("time <= ?", search.endTime)
 	}
 
-	var count uint
-	if err := scope.Count(&count).Error; err != nil {
-		return nil, err
-	}
-
-	transactions := []model.Transaction{}
-
-	err := scope.
-		Offset((search.Page - 1) * search.Limit).
-		Limit(search.Limit).
-		Find(&transactions).
-		Error
-
-	if err != nil {
-		return nil, err
-	}
-
-	result := &PaginatedResult{
-		Page:    search.Page,
-		Limit:   search.Limit,
-		Count:   count,
-		Records: transactions,
-	}
+	return scope
+}
 
-	return result.update(), nil
+// TransactionStats returns transaction stats for a given interval
+func (s TransactionsStore) TransactionStats(bucket string, limit uint) ([]byte, error) {
+	return jsonquery.MustArray(s.reader(), queries.TransactionsTimeStats, bucket, limit)
 }
 
 // Import imports transactions in bulk
@@ -134,6 +247,8 @@ func (s TransactionsStore) Import(records []model.Transaction) error {
 			r.Receiver,
 			r.Amount,
 			r.GasBurnt,
+			r.TokensBurnt,
+			r.Fee,
 			r.Success,
 			string(r.Actions),
 			r.ActionsCount,
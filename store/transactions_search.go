@@ -26,14 +26,8 @@ type TransactionsSearch struct {
 }
 
 func (s *TransactionsSearch) Validate() error {
-	if s.Page == 0 {
-		s.Page = 1
-	}
-	if s.Limit == 0 {
-		s.Limit = paginationLimit
-	}
-	if s.Limit >= paginationLimit {
-		s.Limit = paginationLimit
+	if err := s.Pagination.Validate(); err != nil {
+		return err
 	}
 
 	if t, err := parseTimeFilter(s.StartDate); err == nil {
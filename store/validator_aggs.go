@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/figment-networks/indexing-engine/store/bulk"
+	"github.com/jinzhu/gorm"
+
 	"github.com/figment-networks/near-indexer/model"
 	"github.com/figment-networks/near-indexer/store/queries"
 )
@@ -16,7 +18,7 @@ type ValidatorAggsStore struct {
 func (s ValidatorAggsStore) All() ([]model.ValidatorAgg, error) {
 	result := []model.ValidatorAgg{}
 
-	err := s.db.
+	err := s.reader().
 		Order("id ASC").
 		Find(&result).
 		Error
@@ -28,7 +30,7 @@ func (s ValidatorAggsStore) All() ([]model.ValidatorAgg, error) {
 func (s ValidatorAggsStore) Top() ([]model.ValidatorAgg, error) {
 	result := []model.ValidatorAgg{}
 
-	err := s.db.
+	err := s.reader().
 		Order("efficiency DESC, produced_blocks DESC, last_height DESC").
 		Find(&result).
 		Error
@@ -40,7 +42,7 @@ func (s ValidatorAggsStore) Top() ([]model.ValidatorAgg, error) {
 func (s ValidatorAggsStore) FindValidatorEpochs(account string, limit int) ([]model.ValidatorEpoch, error) {
 	result := []model.ValidatorEpoch{}
 
-	err := s.db.
+	err := s.reader().
 		Model(&model.ValidatorEpoch{}).
 		Where("account_id = ?", account).Order("last_height DESC").
 		Limit(limit).
@@ -56,21 +58,21 @@ func (s ValidatorAggsStore) PaginateValidatorEpochs(account string, pagination P
 		return nil, err
 	}
 
-	scope := s.db.
+	scope := s.reader().
 		Model(&model.ValidatorEpoch{}).
-		Where("account_id = ?", account).
-		Order("last_height DESC")
+		Where("account_id = ?", account)
 
 	var count uint
-	if err := scope.Count(&count).Error; err != nil {
-		return nil, err
+	if !pagination.SkipCount {
+		if err := scope.Count(&count).Error; err != nil {
+			return nil, err
+		}
 	}
 
 	result := []model.ValidatorEpoch{}
 
-	err := scope.
-		Offset((pagination.Page - 1) * pagination.Limit).
-		Limit(pagination.Limit).
+	err := pagination.
+		paginate(scope, "last_height").
 		Find(&result).
 		Error
 
@@ -78,20 +80,98 @@ func (s ValidatorAggsStore) PaginateValidatorEpochs(account string, pagination P
 		return nil, err
 	}
 
-	paginatedResult := &PaginatedResult{
-		Page:    pagination.Page,
-		Limit:   pagination.Limit,
-		Count:   count,
-		Records: result,
+	paginatedResult := pagination.paginateResult(result, count, func(i int) Cursor {
+		return Cursor{Height: uint64(result[i].LastHeight), ID: result[i].ID}
+	})
+
+	return paginatedResult, nil
+}
+
+// PaginateValidatorEpochsByAccounts returns a page of validator epochs for each of the accounts in a single query
+func (s ValidatorAggsStore) PaginateValidatorEpochsByAccounts(accounts []string, pagination Pagination) (map[string]*PaginatedResult, error) {
+	if err := pagination.Validate(); err != nil {
+		return nil, err
+	}
+	if len(accounts) == 0 {
+		return map[string]*PaginatedResult{}, nil
+	}
+
+	scope := func(account string) *gorm.DB {
+		return s.reader().Model(&model.ValidatorEpoch{}).Where("account_id = ?", account)
+	}
+
+	counts, err := pagination.countBatch(s.reader(), accounts, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []struct {
+		BatchKey string
+		model.ValidatorEpoch
+	}{}
+
+	if err := pagination.paginateBatch(s.reader(), accounts, "last_height", scope).Scan(&rows).Error; err != nil {
+		return nil, err
 	}
 
-	return paginatedResult.update(), nil
+	grouped := map[string][]model.ValidatorEpoch{}
+	for _, row := range rows {
+		grouped[row.BatchKey] = append(grouped[row.BatchKey], row.ValidatorEpoch)
+	}
+
+	result := make(map[string]*PaginatedResult, len(accounts))
+	for _, account := range accounts {
+		epochs := append([]model.ValidatorEpoch{}, grouped[account]...)
+		result[account] = pagination.paginateResult(epochs, counts[account], func(i int) Cursor {
+			return Cursor{Height: uint64(epochs[i].LastHeight), ID: epochs[i].ID}
+		})
+	}
+
+	return result, nil
+}
+
+// ExportValidatorEpochs streams all validator epochs for an account in chronological order
+func (s ValidatorAggsStore) ExportValidatorEpochs(account string, fn func(*model.ValidatorEpoch) error) error {
+	query := s.reader().
+		Model(&model.ValidatorEpoch{}).
+		Where("account_id = ?", account).
+		Order("last_height ASC, id ASC").
+		QueryExpr()
+
+	return streamQuery(s.reader(), query, func(scan func(interface{}) error) error {
+		record := &model.ValidatorEpoch{}
+		if err := scan(record); err != nil {
+			return err
+		}
+		return fn(record)
+	})
+}
+
+// FindByAccountIDs returns validator aggs for the given account IDs
+func (s ValidatorAggsStore) FindByAccountIDs(ids []string) ([]model.ValidatorAgg, error) {
+	result := []model.ValidatorAgg{}
+	err := s.reader().Where("account_id IN (?)", ids).Find(&result).Error
+	return result, err
+}
+
+// SearchByPrefix returns validator aggs with account IDs starting with the prefix
+func (s ValidatorAggsStore) SearchByPrefix(prefix string, limit uint) ([]model.ValidatorAgg, error) {
+	result := []model.ValidatorAgg{}
+
+	err := s.reader().
+		Where("account_id LIKE ?", likePrefix(prefix)).
+		Order("account_id ASC").
+		Limit(limit).
+		Find(&result).
+		Error
+
+	return result, err
 }
 
 // FindBy returns an validator agg record for a key and value
 func (s ValidatorAggsStore) FindBy(key string, value interface{}) (*model.ValidatorAgg, error) {
 	result := &model.ValidatorAgg{}
-	err := findBy(s.db, result, key, value)
+	err := findBy(s.reader(), result, key, value)
 	return result, checkErr(err)
 }
 
@@ -106,7 +186,10 @@ func (s ValidatorAggsStore) ImportValidatorEpochs(records []model.ValidatorEpoch
 			r.LastTime,
 			r.ExpectedBlocks,
 			r.ProducedBlocks,
+			r.ExpectedChunks,
+			r.ProducedChunks,
 			r.Efficiency,
+			r.Uptime,
 			r.StakingBalance,
 			r.RewardFee,
 		}
@@ -132,9 +215,12 @@ func (s ValidatorAggsStore) Import(records []model.ValidatorAgg) error {
 			r.AccountID,
 			r.ExpectedBlocks,
 			r.ProducedBlocks,
+			r.ExpectedChunks,
+			r.ProducedChunks,
 			r.Slashed,
 			r.Stake,
 			r.Efficiency,
+			r.Uptime,
 			r.Active,
 			r.RewardFee,
 			t,
@@ -0,0 +1,47 @@
+package store
+
+import (
+	"time"
+
+	"github.com/figment-networks/indexing-engine/store/bulk"
+
+	"github.com/figment-networks/near-indexer/model"
+	"github.com/figment-networks/near-indexer/store/queries"
+)
+
+// ValidatorProposalsStore manages validator proposals records
+type ValidatorProposalsStore struct {
+	baseStore
+}
+
+// Recent returns the most recent proposals for a given account
+func (s ValidatorProposalsStore) Recent(account string, limit int) ([]model.ValidatorProposal, error) {
+	result := []model.ValidatorProposal{}
+
+	err := s.reader().
+		Where("account_id = ?", account).
+		Order("height DESC").
+		Limit(limit).
+		Find(&result).
+		Error
+
+	return result, checkErr(err)
+}
+
+// Import creates validator proposals in batch
+func (s ValidatorProposalsStore) Import(records []model.ValidatorProposal) error {
+	t := time.Now()
+
+	return s.bulkImport(queries.ValidatorProposalsImport, len(records), func(i int) bulk.Row {
+		r := records[i]
+		return bulk.Row{
+			r.Height,
+			r.Time,
+			r.Epoch,
+			r.AccountID,
+			r.PublicKey,
+			r.Stake,
+			t,
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/figment-networks/indexing-engine/store/bulk"
+	"github.com/figment-networks/indexing-engine/store/jsonquery"
 	"github.com/figment-networks/near-indexer/model"
 	"github.com/figment-networks/near-indexer/model/types"
 	"github.com/figment-networks/near-indexer/store/queries"
@@ -18,7 +19,7 @@ type ValidatorsStore struct {
 func (s ValidatorsStore) ByHeight(height types.Height) ([]model.Validator, error) {
 	result := []model.Validator{}
 
-	err := s.db.
+	err := s.reader().
 		Where("height = ?", height).
 		Order("id DESC").
 		Find(&result).
@@ -27,6 +28,11 @@ func (s ValidatorsStore) ByHeight(height types.Height) ([]model.Validator, error
 	return result, err
 }
 
+// ValidatorStats returns validator stats for a given interval
+func (s ValidatorsStore) ValidatorStats(bucket string, limit uint) ([]byte, error) {
+	return jsonquery.MustArray(s.reader(), queries.ValidatorsTimeStats, bucket, limit)
+}
+
 // Import creates new validators in batch
 func (s ValidatorsStore) Import(records []model.Validator) error {
 	t := time.Now()
@@ -40,9 +46,12 @@ func (s ValidatorsStore) Import(records []model.Validator) error {
 			r.Epoch,
 			r.ExpectedBlocks,
 			r.ProducedBlocks,
+			r.ExpectedChunks,
+			r.ProducedChunks,
 			r.Slashed,
 			r.Stake,
 			r.Efficiency,
+			r.Uptime,
 			r.RewardFee,
 			t,
 			t,
@@ -0,0 +1,134 @@
+package store
+
+import (
+	"errors"
+	"time"
+
+	"github.com/figment-networks/near-indexer/model"
+	"github.com/figment-networks/near-indexer/store/queries"
+)
+
+// WebhooksStore manages webhooks and their deliveries
+type WebhooksStore struct {
+	baseStore
+}
+
+type WebhookDeliveriesSearch struct {
+	Pagination
+
+	WebhookID int64  `form:"-"`
+	Status    string `form:"status" enum:"pending,success,dead"`
+}
+
+func (s *WebhookDeliveriesSearch) Validate() error {
+	switch s.Status {
+	case "", model.WebhookDeliveryPending, model.WebhookDeliverySuccess, model.WebhookDeliveryDead:
+	default:
+		return errors.New("invalid delivery status: " + s.Status)
+	}
+	if s.Cursor != "" {
+		return errCursorNotSupported
+	}
+	return s.Pagination.Validate()
+}
+
+// FindByOwner returns all webhooks registered with an API key
+func (s WebhooksStore) FindByOwner(keyID int64) ([]model.Webhook, error) {
+	result := []model.Webhook{}
+
+	err := s.db.
+		Where("api_key_id = ?", keyID).
+		Order("id ASC").
+		Find(&result).
+		Error
+
+	return result, err
+}
+
+// FindOwnedByID returns a webhook for a given ID registered with an API key
+func (s WebhooksStore) FindOwnedByID(keyID int64, id int64) (*model.Webhook, error) {
+	result := &model.Webhook{}
+
+	err := s.db.
+		Where("id = ? AND api_key_id = ?", id, keyID).
+		Take(result).
+		Error
+
+	return result, checkErr(err)
+}
+
+// FindByID returns a webhook for a given ID
+func (s WebhooksStore) FindByID(id int64) (*model.Webhook, error) {
+	result := &model.Webhook{}
+	err := findBy(s.db, result, "id", id)
+	return result, checkErr(err)
+}
+
+// Delete removes the webhook along with its deliveries
+func (s WebhooksStore) Delete(id int64) error {
+	return s.db.Delete(&model.Webhook{}, "id = ?", id).Error
+}
+
+// EnqueueDeliveries creates pending deliveries for events and transactions in the height range
+func (s WebhooksStore) EnqueueDeliveries(heightRange HeightRange) error {
+	if err := s.db.Exec(queries.WebhooksEnqueueEvents, heightRange.Start, heightRange.End).Error; err != nil {
+		return err
+	}
+	return s.db.Exec(queries.WebhooksEnqueueTransactions, heightRange.Start, heightRange.End).Error
+}
+
+// ClaimDeliveries returns deliveries that are due for the next attempt, up to perWebhook
+// deliveries of each webhook. Claimed deliveries are postponed for the lease duration,
+// so other workers skip them while they're being sent.
+func (s WebhooksStore) ClaimDeliveries(limit int, perWebhook int, lease time.Duration) ([]model.WebhookDelivery, error) {
+	result := []model.WebhookDelivery{}
+
+	err := s.db.
+		Raw(queries.WebhooksClaimDeliveries, model.WebhookDeliveryPending, perWebhook, limit, lease.Seconds()).
+		Scan(&result).
+		Error
+
+	return result, err
+}
+
+// Deliveries returns a paginated delivery log of a webhook
+func (s WebhooksStore) Deliveries(search WebhookDeliveriesSearch) (*PaginatedResult, error) {
+	if err := search.Validate(); err != nil {
+		return nil, err
+	}
+
+	scope := s.db.
+		Model(&model.WebhookDelivery{}).
+		Where("webhook_id = ?", search.WebhookID)
+
+	if search.Status != "" {
+		scope = scope.Where("status = ?", search.Status)
+	}
+
+	var count uint
+	if err := scope.Count(&count).Error; err != nil {
+		return nil, err
+	}
+
+	deliveries := []model.WebhookDelivery{}
+
+	err := scope.
+		Order("id DESC").
+		Offset((search.Page - 1) * search.Limit).
+		Limit(search.Limit).
+		Find(&deliveries).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PaginatedResult{
+		Page:    search.Page,
+		Limit:   search.Limit,
+		Count:   count,
+		Records: deliveries,
+	}
+
+	return result.update(), nil
+}